@@ -0,0 +1,253 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+// sysDescrInstance is SNMPv2-MIB::sysDescr.0, used as the PRODUCT-RELEASE
+// of a generated capability statement when the walk covered it.
+const sysDescrInstance = "1.3.6.1.2.1.1.1.0"
+
+// capabilityEnterprise is the private enterprise number RFC 5612 reserves
+// for documentation, under which drafts are registered until published.
+const capabilityEnterprise = 32473
+
+// capabilityDraft is an AGENT-CAPABILITIES statement inferred from a walk,
+// expressed with the same SUPPORTS/VARIATION model gomib resolves from
+// capability modules.
+type capabilityDraft struct {
+	target   string
+	source   string // result group label the draft was built from
+	release  string
+	created  time.Time
+	supports []mib.CapabilitiesModule
+}
+
+// buildCapabilityDraft infers a capability statement from the instances in
+// a complete walk. A group is included when the walk returned at least one
+// of its objects; included objects under the walked subtree that the walk
+// did not return become not-implemented variations, and enumerated objects
+// that only ever showed a subset of their values get a SYNTAX variation
+// listing that subset. Objects outside the subtree say nothing either way.
+func buildCapabilityDraft(g *snmp.ResultGroup, m *mib.Mib, target string) (*capabilityDraft, error) {
+	observed := make(map[*mib.Object][]gosnmp.SnmpPDU)
+	d := &capabilityDraft{target: target, source: g.Label, release: "unknown", created: time.Now().UTC()}
+	for _, res := range g.Results {
+		if !snmp.HasValue(res.PDU) {
+			continue
+		}
+		oid, err := mib.ParseOID(res.OID)
+		if err != nil {
+			continue
+		}
+		if strings.TrimPrefix(res.OID, ".") == sysDescrInstance {
+			d.release = res.Value
+		}
+		node := m.LongestPrefixByOID(oid)
+		if node == nil || node.Object() == nil || (!node.Object().IsScalar() && !node.Object().IsColumn()) {
+			continue
+		}
+		observed[node.Object()] = append(observed[node.Object()], res.PDU)
+	}
+	if len(observed) == 0 {
+		return nil, errors.New("no MIB objects in " + g.Label)
+	}
+
+	modules := make(map[*mib.Module]bool)
+	for obj := range observed {
+		if obj.Module() != nil {
+			modules[obj.Module()] = true
+		}
+	}
+	sorted := make([]*mib.Module, 0, len(modules))
+	for mod := range modules {
+		sorted = append(sorted, mod)
+	}
+	slices.SortFunc(sorted, func(a, b *mib.Module) int { return strings.Compare(a.Name(), b.Name()) })
+
+	for _, mod := range sorted {
+		cm := mib.CapabilitiesModule{ModuleName: mod.Name()}
+		varied := make(map[*mib.Object]bool)
+		for _, grp := range mod.Groups() {
+			if grp.IsNotificationGroup() || grp.Status() == mib.StatusObsolete {
+				continue
+			}
+			objs := groupObjects(grp)
+			if !slices.ContainsFunc(objs, func(o *mib.Object) bool { return len(observed[o]) > 0 }) {
+				continue
+			}
+			cm.Includes = append(cm.Includes, grp.Name())
+			for _, obj := range objs {
+				if varied[obj] {
+					continue
+				}
+				if len(observed[obj]) == 0 && (obj.OID() == nil || !obj.OID().HasPrefix(g.WalkRootOID)) {
+					continue // not walked, so its absence is unknown
+				}
+				if ov, ok := observedVariation(obj, observed[obj]); ok {
+					varied[obj] = true
+					cm.ObjectVariations = append(cm.ObjectVariations, ov)
+				}
+			}
+		}
+		if len(cm.Includes) > 0 {
+			d.supports = append(d.supports, cm)
+		}
+	}
+	if len(d.supports) == 0 {
+		return nil, errors.New("no object groups cover the walked objects")
+	}
+	return d, nil
+}
+
+// observedVariation returns the VARIATION describing how the agent's
+// instances of obj differ from its declared syntax, if they do.
+func observedVariation(obj *mib.Object, pdus []gosnmp.SnmpPDU) (mib.ObjectVariation, bool) {
+	if !isReadable(obj.Access()) {
+		return mib.ObjectVariation{}, false
+	}
+	if len(pdus) == 0 {
+		notImpl := mib.AccessNotImplemented
+		return mib.ObjectVariation{
+			Object:      obj.Name(),
+			Access:      &notImpl,
+			Description: "Not returned by the agent.",
+		}, true
+	}
+
+	enums := obj.EffectiveEnums()
+	if len(enums) < 2 {
+		return mib.ObjectVariation{}, false
+	}
+	seen := make(map[int64]bool)
+	for _, pdu := range pdus {
+		if v, ok := snmp.IntegerValue(pdu); ok {
+			seen[v] = true
+		}
+	}
+	var subset []mib.NamedValue
+	for _, nv := range enums {
+		if seen[nv.Value] {
+			subset = append(subset, nv)
+		}
+	}
+	if len(subset) == 0 || len(subset) == len(enums) {
+		return mib.ObjectVariation{}, false
+	}
+	return mib.ObjectVariation{
+		Object:      obj.Name(),
+		Syntax:      &mib.SyntaxConstraints{Enums: subset},
+		Description: fmt.Sprintf("Only these values were observed across %d instances.", len(pdus)),
+	}, true
+}
+
+// capabilityIdent turns a target address into an identifier fragment.
+func capabilityIdent(target string) string {
+	var b strings.Builder
+	for _, r := range target {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// moduleName returns the generated module's name.
+func (d *capabilityDraft) moduleName() string {
+	return "MIBSH-" + strings.ToUpper(capabilityIdent(d.target)) + "-CAPABILITY-MIB"
+}
+
+func (d *capabilityDraft) fileName() string {
+	return d.moduleName() + ".mib"
+}
+
+// smiText makes s safe inside an SMI quoted string, which holds printable
+// ASCII only: quotes become apostrophes, whitespace and control characters
+// collapse to single spaces, and other characters become '?'.
+func smiText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '"':
+			return '\''
+		case r < ' ' || r == 0x7f:
+			return ' '
+		case r > '~':
+			return '?'
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// render returns the draft as SMIv2 module source.
+func (d *capabilityDraft) render() []reportLine {
+	var b reportBuilder
+
+	b.add(reportMuted, "-- Draft generated by mibsh from %q on %s.", smiText(d.source), smiText(d.target))
+	b.add(reportMuted, "-- Review before publishing: a walk cannot show write access,")
+	b.add(reportMuted, "-- and value restrictions reflect only the instances observed.")
+	b.blank()
+	b.add(reportHeading, "%s DEFINITIONS ::= BEGIN", d.moduleName())
+	b.blank()
+	b.add(reportPlain, "IMPORTS")
+	b.add(reportPlain, "    MODULE-IDENTITY, enterprises  FROM SNMPv2-SMI")
+	b.add(reportPlain, "    AGENT-CAPABILITIES            FROM SNMPv2-CONF;")
+	b.blank()
+	b.add(reportHeading, "mibshCapabilityMIB MODULE-IDENTITY")
+	b.add(reportPlain, "    LAST-UPDATED \"%s\"", d.created.Format("200601021504Z"))
+	b.add(reportPlain, "    ORGANIZATION \"Unknown; set before publishing.\"")
+	b.add(reportPlain, "    CONTACT-INFO \"Unknown; set before publishing.\"")
+	b.add(reportPlain, "    DESCRIPTION  \"Capabilities of %s, drafted by mibsh from a walk.\"", smiText(d.target))
+	b.add(reportMuted, "    -- Replace with an OID under your organization's enterprise arc.")
+	b.add(reportPlain, "    ::= { enterprises %d }", capabilityEnterprise)
+	b.blank()
+	b.add(reportHeading, "mibshAgentCapability AGENT-CAPABILITIES")
+	b.add(reportPlain, "    PRODUCT-RELEASE \"%s\"", smiText(d.release))
+	b.add(reportPlain, "    STATUS          current")
+	b.add(reportPlain, "    DESCRIPTION     \"Capabilities observed on %s.\"", smiText(d.target))
+	for _, sm := range d.supports {
+		b.blank()
+		b.add(reportPlain, "    SUPPORTS        %s", sm.ModuleName)
+		for i, inc := range sm.Includes {
+			prefix, suffix := "                      ", ","
+			if i == 0 {
+				prefix = "    INCLUDES        { "
+			}
+			if i == len(sm.Includes)-1 {
+				suffix = " }"
+			}
+			b.add(reportPlain, "%s%s%s", prefix, inc, suffix)
+		}
+		for _, ov := range sm.ObjectVariations {
+			b.blank()
+			b.add(reportPlain, "    VARIATION       %s", ov.Object)
+			if ov.Syntax != nil {
+				vals := make([]string, len(ov.Syntax.Enums))
+				for i, nv := range ov.Syntax.Enums {
+					vals[i] = fmt.Sprintf("%s(%d)", nv.Label, nv.Value)
+				}
+				b.add(reportWarn, "        SYNTAX      INTEGER { %s }", strings.Join(vals, ", "))
+			}
+			if ov.Access != nil {
+				b.add(reportBad, "        ACCESS      %s", ov.Access)
+			}
+			b.add(reportPlain, "        DESCRIPTION \"%s\"", smiText(ov.Description))
+		}
+	}
+	b.blank()
+	b.add(reportPlain, "    ::= { mibshCapabilityMIB 1 }")
+	b.blank()
+	b.add(reportHeading, "END")
+	return b.lines
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+func TestCapabilityDraftLoads(t *testing.T) {
+	m := loadTestMib(t)
+	tbl := m.Object("testCtlTable")
+	g := &snmp.ResultGroup{
+		Op:          snmp.OpWalk,
+		Label:       "WALK testCtlTable",
+		WalkRootOID: tbl.OID(),
+	}
+	add := func(oid string, pdu gosnmp.SnmpPDU, value string) {
+		pdu.Name = "." + oid
+		g.Results = append(g.Results, snmp.Result{OID: oid, Value: value, PDU: pdu})
+	}
+	for _, col := range []string{"testCtlDescr", "testCtlAdminStatus", "testCtlRowStatus"} {
+		oid := m.Object(col).OID().String() + ".1"
+		if col == "testCtlDescr" {
+			add(oid, gosnmp.SnmpPDU{Type: gosnmp.OctetString, Value: []byte("daily")}, "daily")
+		} else {
+			add(oid, gosnmp.SnmpPDU{Type: gosnmp.Integer, Value: 1}, "1")
+		}
+	}
+	release := "Acme OS 1.0\r\n\tbuild \"7\"\x01 é"
+	add(sysDescrInstance, gosnmp.SnmpPDU{Type: gosnmp.OctetString, Value: []byte(release)}, release)
+
+	d, err := buildCapabilityDraft(g, m, "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Acme OS 1.0 build '7' ?"; smiText(d.release) != want {
+		t.Errorf("release = %q, want %q", smiText(d.release), want)
+	}
+
+	var src strings.Builder
+	for _, l := range d.render() {
+		src.WriteString(l.text)
+		src.WriteByte('\n')
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, d.fileName()), []byte(src.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadMib([]string{"testdata/mibs", dir}, nil, nil, false, func(msg string) { t.Log(msg) })
+	if err != nil {
+		t.Fatalf("draft does not load: %v\n%s", err, src.String())
+	}
+	for _, diag := range loaded.Diagnostics() {
+		if diag.Module == d.moduleName() && diag.Severity <= mib.SeverityError {
+			t.Errorf("draft: %s", diag)
+		}
+	}
+	mod := loaded.Module(d.moduleName())
+	if mod == nil || len(mod.Capabilities()) != 1 {
+		t.Fatalf("draft module has no capability statement:\n%s", src.String())
+	}
+	sup := mod.Capabilities()[0].Supports()
+	if len(sup) != 1 || len(sup[0].ObjectVariations) != 3 {
+		t.Errorf("supports = %+v, want MIBSH-TEST-MIB with three variations", sup)
+	}
+}
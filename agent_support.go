@@ -0,0 +1,176 @@
+package main
+
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+)
+
+// probeRoot is a subtree whose registered nodes all belong to one module.
+type probeRoot struct {
+	module string
+	node   *mib.Node
+}
+
+// discoverDoneMsg pairs a discovery result with the roots that were probed,
+// so the result is attributed to modules as they were when it started.
+type discoverDoneMsg struct {
+	roots []probeRoot
+	snmp.DiscoverMsg
+}
+
+// moduleProbeRoots returns, for every loaded module, the maximal subtrees
+// owned entirely by that module. Probing each root with GETNEXT tells
+// whether an agent has any instances for the module without walking it.
+// Subtrees shared between modules (mib-2, enterprises, ...) are descended
+// into until the ownership is unambiguous.
+func moduleProbeRoots(root *mib.Node) []probeRoot {
+	owners := make(map[*mib.Node]string)
+	subtreeOwner(root, owners)
+
+	var roots []probeRoot
+	var collect func(n *mib.Node)
+	collect = func(n *mib.Node) {
+		owner, ok := owners[n]
+		if !ok {
+			for _, child := range n.Children() {
+				collect(child)
+			}
+			return
+		}
+		if owner != "" && len(n.OID()) >= 2 {
+			roots = append(roots, probeRoot{module: owner, node: n})
+		}
+	}
+	for _, child := range root.Children() {
+		collect(child)
+	}
+	return roots
+}
+
+// probeRootIndex returns the OIDs to probe and a map from each OID string
+// to its owning module.
+func probeRootIndex(roots []probeRoot) ([]mib.OID, map[string]string) {
+	oids := make([]mib.OID, len(roots))
+	moduleOf := make(map[string]string, len(roots))
+	for i, r := range roots {
+		oids[i] = r.node.OID()
+		moduleOf[oids[i].String()] = r.module
+	}
+	return oids, moduleOf
+}
+
+// subtreeOwner records in owners the single module owning each subtree, ""
+// for subtrees without any module, and leaves mixed subtrees absent. It
+// returns the owner and whether the subtree is uniform.
+func subtreeOwner(n *mib.Node, owners map[*mib.Node]string) (string, bool) {
+	owner := ""
+	if mod := n.Module(); mod != nil {
+		owner = mod.Name()
+	}
+	uniform := true
+	for _, child := range n.Children() {
+		co, ok := subtreeOwner(child, owners)
+		switch {
+		case !ok:
+			uniform = false
+		case co == "":
+		case owner == "":
+			owner = co
+		case co != owner:
+			uniform = false
+		}
+	}
+	if uniform {
+		owners[n] = owner
+	}
+	return owner, uniform
+}
+
+// agentSupport records what the connected agent answered for during
+// capability discovery. The tree and the CEL filters share one instance,
+// so it is reset in place rather than replaced.
+type agentSupport struct {
+	target   string
+	sysOR    []snmp.SysOREntry
+	roots    map[string]bool // answered probe roots, by OID string
+	contains map[string]bool // ancestors of answered roots, by OID string
+	modules  map[string]int  // module name -> answered root count
+	probed   int
+	complete bool // discovery ran to completion for target
+}
+
+// reset clears the discovery state, e.g. after connecting to another agent.
+func (s *agentSupport) reset() {
+	*s = agentSupport{}
+}
+
+// active reports whether any discovery results are loaded.
+func (s *agentSupport) active() bool {
+	return s != nil && s.roots != nil
+}
+
+// set loads discovery results. moduleOf maps probe root OIDs to modules.
+func (s *agentSupport) set(target string, msg snmp.DiscoverMsg, moduleOf map[string]string) {
+	s.target = target
+	s.sysOR = msg.SysOR
+	s.probed = msg.Probed
+	s.complete = msg.Err == nil
+	s.roots = make(map[string]bool, len(msg.Answered))
+	s.contains = make(map[string]bool)
+	s.modules = make(map[string]int)
+	for _, oid := range msg.Answered {
+		s.roots[oid] = true
+		if mod := moduleOf[oid]; mod != "" {
+			s.modules[mod]++
+		}
+		if parsed, err := mib.ParseOID(oid); err == nil {
+			for p := parsed.Parent(); len(p) > 0; p = p.Parent() {
+				s.contains[p.String()] = true
+			}
+		}
+	}
+}
+
+// implements reports whether the agent answered for the node: the node or
+// one of its ancestors is a probe root that returned an instance.
+func (s *agentSupport) implements(node *mib.Node) bool {
+	if !s.active() {
+		return false
+	}
+	for n := node; n != nil; n = n.Parent() {
+		if s.roots[n.OID().String()] {
+			return true
+		}
+	}
+	return false
+}
+
+// treeMarker returns the tree annotation for a node: a check on answered
+// probe roots, a dot on nodes containing answered subtrees, "" otherwise.
+// bg supplies the row background so the marker blends into selected rows.
+func (s *agentSupport) treeMarker(node *mib.Node, bg lipgloss.Style) string {
+	if !s.active() {
+		return ""
+	}
+	oid := node.OID().String()
+	switch {
+	case s.roots[oid]:
+		return bg.Render(" ") + styles.Tree.Implemented.Inherit(bg).Render(IconSuccess)
+	case s.contains[oid]:
+		return bg.Render(" ") + styles.Tree.ImplementedWithin.Inherit(bg).Render(IconPending)
+	}
+	return ""
+}
+
+// moduleNames returns the modules with at least one answered root, sorted.
+func (s *agentSupport) moduleNames() []string {
+	names := make([]string, 0, len(s.modules))
+	for name := range s.modules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -29,6 +29,8 @@ const (
 	focusWatch
 	focusXref
 	focusColumnPicker
+	focusFilePicker
+	focusTableFilter
 )
 
 // topPane controls which view occupies the top-right sub-pane.
@@ -40,6 +42,8 @@ const (
 	topTableSchema
 	topModule
 	topTypes
+	topReport
+	topSource
 )
 
 // bottomPane controls which view occupies the bottom-right sub-pane.
@@ -51,6 +55,10 @@ const (
 	bottomResults
 	bottomTableData
 	bottomWatch
+	bottomScan
+	bottomInterfaces
+	bottomInventory
+	bottomNeighbors
 )
 
 func (f focus) String() string {
@@ -81,6 +89,10 @@ func (f focus) String() string {
 		return "xref"
 	case focusColumnPicker:
 		return "column-picker"
+	case focusFilePicker:
+		return "file-picker"
+	case focusTableFilter:
+		return "table-filter"
 	default:
 		return fmt.Sprintf("unknown(%d)", f)
 	}
@@ -98,6 +110,10 @@ func (p topPane) String() string {
 		return "module"
 	case topTypes:
 		return "types"
+	case topReport:
+		return "report"
+	case topSource:
+		return "source"
 	default:
 		return fmt.Sprintf("unknown(%d)", p)
 	}
@@ -113,6 +129,14 @@ func (p bottomPane) String() string {
 		return "table-data"
 	case bottomWatch:
 		return "watch"
+	case bottomScan:
+		return "scan"
+	case bottomInterfaces:
+		return "interfaces"
+	case bottomInventory:
+		return "inventory"
+	case bottomNeighbors:
+		return "neighbors"
 	default:
 		return fmt.Sprintf("unknown(%d)", p)
 	}
@@ -141,9 +165,19 @@ type appLayout struct {
 
 // appConfig holds CLI-provided configuration.
 type appConfig struct {
+	mibPaths   []string // -p paths, empty for the system locations
+	mibModules []string
+	permissive bool
+	watchMibs  bool
+
 	target    string
 	community string
 	version   string
+	oidOrder  string
+
+	gentle      bool
+	rateLimit   float64
+	maxParallel int
 }
 
 // model is passed by value to bubbletea (not as *model). Update and View use
@@ -160,6 +194,8 @@ type model struct {
 	filterBar    filterBarModel
 	diag         diagModel
 	tableSchema  tableSchemaModel
+	report       reportModel
+	source       sourceModel
 	module       moduleModel
 	typeBrowser  typeModel
 	overlay      overlayModel
@@ -179,22 +215,40 @@ type model struct {
 	xrefs        xrefMap
 	xrefPicker   xrefPickerModel
 	columnPicker columnPickerModel
+	filePicker   filePickerModel
 
 	queryBar queryBarModel
 
 	snmp         *snmp.Session
 	walk         *snmp.WalkSession
+	tableFetch   *snmp.TableFetch // in-progress table fetch, nil when idle
 	results      resultModel
 	tableData    tableDataModel
-	tableDataObj *mib.Object // the *mib.Object for the current table data fetch
+	tableDataObj *mib.Object      // the *mib.Object for the current table data fetch
+	support      *agentSupport    // capability discovery results, shared with tree and filters
+	discovering  bool             // capability discovery in flight
+	compliance   *complianceCheck // in-flight compliance check, nil when idle
+	summary      *summaryRequest  // in-flight device summary, nil when idle
+	identifying  bool             // connect-time sysObjectID read in flight
+	setting      bool             // row editor SET in flight
 	watch        watchModel
+	scan         *snmp.ScanSession // in-progress subnet sweep, nil when idle
+	scanList     scanModel
+	ifaces       ifaceModel     // interface dashboard
+	inventory    inventoryModel // physical entity tree
+	neighbors    neighborModel  // LLDP/CDP neighbors and hop path
 	dialog       *deviceDialogModel
+	rowEditor    *rowEditorModel
 	config       appConfig
 	profiles     *profile.Store
 	lastDevice   profile.Device // last successful connection, for saving
-	pendingChord string         // active chord prefix ("s", "c", "v") or empty
+	identity     deviceIdentity // what the agent's sysObjectID says, zero until read
+	pendingChord string         // active chord prefix ("s", "c", "v", "m") or empty
 	contextMenu  contextMenuModel
 	navStack     []*mib.Node // back-navigation stack (capped at 50)
+	mibReloading bool        // a MIB reload is loading in the background
+	mibWatch     mibWatcher  // polls the MIB paths when watching
+	extraMibs    []string    // MIB files and directories loaded at runtime
 
 	moduleFirstNode map[string]*mib.Node // module name -> first node in that module
 	cachedLayout    appLayout            // layout computed once per Update/View frame
@@ -222,6 +276,14 @@ func newApp(m *mib.Mib, cfg appConfig, profiles *profile.Store) model {
 
 	results := newResultModel()
 	results.mib = m
+	tableData := newTableDataModel()
+	tableData.mib = m
+
+	support := &agentSupport{}
+	tree.support = support
+	filterBar.filter.support = support
+	results.celFilter.support = support
+
 	watch := newWatchModel()
 
 	// Pre-build the device for auto-connect so Init() can use it.
@@ -233,12 +295,22 @@ func newApp(m *mib.Mib, cfg appConfig, profiles *profile.Store) model {
 				Target:    cfg.target,
 				Community: cfg.community,
 				Version:   cfg.version,
+				OIDOrder:  cfg.oidOrder,
+
+				Gentle:      cfg.gentle,
+				RateLimit:   cfg.rateLimit,
+				MaxParallel: cfg.maxParallel,
 			},
 		}
 	}
 
 	modFirstNode := buildModuleFirstNode(m)
 
+	var mibWatch mibWatcher
+	if cfg.watchMibs {
+		mibWatch.start(cfg.mibPaths) // Init schedules the first tick
+	}
+
 	return model{
 		mib:             m,
 		tree:            tree,
@@ -247,16 +319,25 @@ func newApp(m *mib.Mib, cfg appConfig, profiles *profile.Store) model {
 		filterBar:       filterBar,
 		diag:            diag,
 		tableSchema:     ts,
+		report:          newReportModel(),
+		source:          newSourceModel(),
 		module:          mod,
 		typeBrowser:     typBrowser,
 		xrefs:           xrefs,
 		xrefPicker:      newXrefPicker(m),
 		columnPicker:    newColumnPicker(),
+		filePicker:      newFilePicker(),
 		queryBar:        newQueryBar(m),
 		results:         results,
-		tableData:       newTableDataModel(),
+		support:         support,
+		tableData:       tableData,
 		watch:           watch,
+		scanList:        newScanModel(),
+		ifaces:          newIfaceModel(),
+		inventory:       newInventoryModel(),
+		neighbors:       newNeighborModel(),
 		moduleFirstNode: modFirstNode,
+		mibWatch:        mibWatch,
 		focus:           focusTree,
 		hoverRow:        -1,
 		stats:           stats,
@@ -270,9 +351,9 @@ func newApp(m *mib.Mib, cfg appConfig, profiles *profile.Store) model {
 // activePaneID returns the pane that currently has keyboard focus.
 func (m model) activePaneID() paneID {
 	switch m.focus {
-	case focusResults, focusResultFilter, focusWatch:
+	case focusResults, focusResultFilter, focusTableFilter, focusWatch:
 		return paneRightBot
-	case focusDetail, focusDiag, focusModule, focusTypes, focusXref, focusColumnPicker:
+	case focusDetail, focusDiag, focusModule, focusTypes, focusXref, focusColumnPicker, focusFilePicker:
 		return paneRightTop
 	default:
 		return paneTree
@@ -296,6 +377,10 @@ func (m model) Init() tea.Cmd {
 		cmds = append(cmds, snmp.ConnectCmd(m.lastDevice.Profile))
 	}
 
+	if m.mibWatch.active {
+		cmds = append(cmds, m.mibWatch.tickCmd(m.mibSourcePaths()))
+	}
+
 	return tea.Batch(cmds...)
 }
 
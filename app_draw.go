@@ -8,6 +8,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/golangsnmp/mibsh/internal/snmp"
 )
 
 var (
@@ -81,6 +82,11 @@ func (m model) View() tea.View {
 		m.overlay.drawCentered(canvas, l.area, m.dialog.view())
 	}
 
+	// Row editor overlay
+	if m.overlay.kind == overlayRowEdit && m.rowEditor != nil {
+		m.overlay.drawCentered(canvas, l.area, m.rowEditor.view())
+	}
+
 	// Context menu
 	if m.contextMenu.visible {
 		m.contextMenu.draw(canvas, l.area)
@@ -110,18 +116,21 @@ func (m model) renderPanes(canvas uv.ScreenBuffer, l appLayout) {
 	m.drawBorders(canvas, l)
 
 	// Tree pane - unfocused when another major pane has focus
-	treeFocused := m.focus != focusResults && m.focus != focusResultFilter && m.focus != focusWatch && m.focus != focusDetail
+	treeFocused := m.focus != focusResults && m.focus != focusResultFilter &&
+		m.focus != focusTableFilter && m.focus != focusWatch && m.focus != focusDetail
 	treeContent := styles.Tree.Pane.
 		Width(l.tree.Dx()).
 		Height(l.tree.Dy()).
 		Render(m.tree.view(treeFocused))
 	uv.NewStyledString(treeContent).Draw(canvas, l.tree)
 
-	// Top-right sub-pane (detail/diag/table schema/module/xref picker/column picker)
+	// Top-right sub-pane (detail/diag/table schema/module/source/xref/column/file picker)
 	var topContent string
 	switch m.focus {
 	case focusColumnPicker:
 		topContent = renderPane(l.rightTop, m.columnPicker.view())
+	case focusFilePicker:
+		topContent = renderPane(l.rightTop, m.filePicker.view())
 	case focusXref:
 		topContent = renderPane(l.rightTop, m.xrefPicker.view())
 	default:
@@ -134,6 +143,10 @@ func (m model) renderPanes(canvas uv.ScreenBuffer, l appLayout) {
 			topContent = renderPane(l.rightTop, m.typeBrowser.view())
 		case topTableSchema:
 			topContent = renderPane(l.rightTop, m.tableSchema.view())
+		case topReport:
+			topContent = renderPane(l.rightTop, m.report.view())
+		case topSource:
+			topContent = renderPane(l.rightTop, m.source.view())
 		default:
 			m.detail.resultsFocused = m.focus == focusResults || m.focus == focusResultFilter
 			topContent = renderPane(l.rightTop, m.detail.view(m.focus == focusDetail))
@@ -151,6 +164,14 @@ func (m model) renderPanes(canvas uv.ScreenBuffer, l appLayout) {
 			botContent = renderPane(l.rightBot, m.tableData.view())
 		case bottomWatch:
 			botContent = renderPane(l.rightBot, m.watch.view())
+		case bottomScan:
+			botContent = renderPane(l.rightBot, m.scanList.view())
+		case bottomInterfaces:
+			botContent = renderPane(l.rightBot, m.ifaces.view())
+		case bottomInventory:
+			botContent = renderPane(l.rightBot, m.inventory.view(m.focus == focusResults))
+		case bottomNeighbors:
+			botContent = renderPane(l.rightBot, m.neighbors.view())
 		}
 		if botContent != "" {
 			uv.NewStyledString(botContent).Draw(canvas, l.rightBot)
@@ -163,10 +184,15 @@ func (m model) renderPanes(canvas uv.ScreenBuffer, l appLayout) {
 		bottom = m.search.view()
 	} else if m.focus == focusResultFilter {
 		bottom = m.results.filterView()
+	} else if m.focus == focusTableFilter {
+		bottom = m.tableData.filterView()
 	} else if m.focus == focusFilter {
 		bottom = m.filterBar.view()
 	} else if m.focus == focusQueryBar {
 		bottom = m.queryBar.view()
+	} else if m.bottomPane == bottomTableData && m.tableData.isFiltering() && m.focus == focusResults {
+		// Persistent row filter indicator when table data is focused
+		bottom = m.renderActiveIndicator("/ rows: ", m.tableData.filter.expr, m.tableData.lv.Len())
 	} else if m.results.isFiltering() && m.focus == focusResults {
 		// Persistent result filter indicator when in results focus
 		bottom = m.renderResultFilterIndicator()
@@ -206,12 +232,7 @@ func (m model) renderHeader(width int) string {
 	}
 
 	// Device pills (inline in header)
-	var pills string
-	if m.snmp.IsConnected() {
-		pills = styles.Status.SuccessIcon.Render(IconPending) + " " +
-			styles.Pill.Connected.Render(m.snmp.Target) + " " +
-			styles.Pill.Version.Render("("+m.snmp.Version+")")
-	}
+	pills := m.devicePills()
 
 	// Build right-hand content
 	var rightContent string
@@ -244,6 +265,41 @@ func (m model) renderHeader(width int) string {
 	return styles.Header.Bar.Width(width).Render(line)
 }
 
+// devicePills renders the connected device's target, version, identity and
+// request rate for the header, or "" when not connected.
+func (m model) devicePills() string {
+	if !m.snmp.IsConnected() {
+		return ""
+	}
+	pills := styles.Status.SuccessIcon.Render(IconPending) + " " +
+		styles.Pill.Connected.Render(m.snmp.Target) + " " +
+		styles.Pill.Version.Render("("+m.snmp.Version+")")
+	if id := m.identity.label(); id != "" {
+		pills += " " + styles.Value.Render(id)
+	}
+	if rate := requestRateLabel(m.snmp); rate != "" {
+		pills += " " + styles.Label.Render(rate)
+	}
+	return pills
+}
+
+// requestRateLabel describes the session's recent request rate and its
+// limit for the header, or returns "" for an idle, unlimited session.
+func requestRateLabel(sess *snmp.Session) string {
+	rate, limit := sess.Rate(), sess.RateLimit()
+	var s string
+	switch {
+	case limit > 0:
+		s = fmt.Sprintf("%.1f/%g req/s", rate, limit)
+	case rate > 0:
+		s = fmt.Sprintf("%.1f req/s", rate)
+	}
+	if sess.Gentle {
+		s = strings.TrimSpace("gentle " + s)
+	}
+	return s
+}
+
 // renderHintBar builds the two-line grouped keybind hints for the bottom bar.
 // Line 1: Browse (navigation, search, filter, copy, tab)
 // Line 2: Chord prefixes + meta
@@ -307,7 +363,7 @@ func (m model) renderFilterIndicator() string {
 // renderResultFilterIndicator shows the active result filter and match count
 // when the result filter input is not focused.
 func (m model) renderResultFilterIndicator() string {
-	return m.renderActiveIndicator("/ results: ", m.results.filterQuery, len(m.results.filterIdx))
+	return m.renderActiveIndicator(m.results.filterInput.Prompt, m.results.filterQuery, len(m.results.filterIdx))
 }
 
 // renderPane wraps content in the standard pane style sized to the given rect.
@@ -485,6 +541,8 @@ func renderFullHelp() string {
 	b.WriteString("\n")
 	b.WriteString(h("enter", "cross-reference / jump"))
 	b.WriteString("\n")
+	b.WriteString(h("ctrl+e", "filter: substring/CEL mode"))
+	b.WriteString("\n")
 	b.WriteString(h("h/l", "collapse/expand tree"))
 	b.WriteString("\n\n")
 
@@ -492,11 +550,98 @@ func renderFullHelp() string {
 	b.WriteString("\n")
 	b.WriteString(h("+/-", "adjust poll interval"))
 	b.WriteString("\n")
-	b.WriteString(h("esc", "stop watch"))
+	b.WriteString(h("esc", "stop watch, cancel table fetch"))
 	b.WriteString("\n")
 	b.WriteString(h("</> ", "scroll columns"))
 	b.WriteString("\n")
 	b.WriteString(h("v c", "column picker"))
+	b.WriteString("\n")
+	b.WriteString(h("/", "CEL row filter (table)"))
+	b.WriteString("\n")
+	b.WriteString(h("h/l", "select column (table)"))
+	b.WriteString("\n")
+	b.WriteString(h("o", "sort by selected column (table)"))
+	b.WriteString("\n")
+	b.WriteString(h("r", "refresh table"))
+	b.WriteString("\n")
+	b.WriteString(h("R", "refresh visible columns only"))
+	b.WriteString("\n")
+	b.WriteString(h("n/e/D", "new/edit/destroy row"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hdr.Render("Scan"))
+	b.WriteString("\n")
+	b.WriteString(h("enter", "connect to agent"))
+	b.WriteString("\n")
+	b.WriteString(h("p", "save agent as profile"))
+	b.WriteString("\n")
+	b.WriteString(h("r", "scan again"))
+	b.WriteString("\n")
+	b.WriteString(h("esc", "cancel scan"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hdr.Render("Interfaces"))
+	b.WriteString("\n")
+	b.WriteString(h("enter", "watch interface counters"))
+	b.WriteString("\n")
+	b.WriteString(h("o/O", "next sort column, reverse sort"))
+	b.WriteString("\n")
+	b.WriteString(h("+/-", "adjust poll interval"))
+	b.WriteString("\n")
+	b.WriteString(h("r", "restart polling"))
+	b.WriteString("\n")
+	b.WriteString(h("esc", "pause polling"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hdr.Render("Neighbors"))
+	b.WriteString("\n")
+	b.WriteString(h("enter", "connect to neighbor"))
+	b.WriteString("\n")
+	b.WriteString(h("p", "connect with a chosen profile"))
+	b.WriteString("\n")
+	b.WriteString(h("b", "back one hop"))
+	b.WriteString("\n")
+	b.WriteString(h("r", "read neighbors again"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hdr.Render("Inventory"))
+	b.WriteString("\n")
+	b.WriteString(h("enter", "expand/collapse entity"))
+	b.WriteString("\n")
+	b.WriteString(h("h/l", "collapse/expand"))
+	b.WriteString("\n")
+	b.WriteString(h("r", "read inventory again"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hdr.Render("Load MIBs"))
+	b.WriteString("\n")
+	b.WriteString(h("enter/l", "open directory, load file"))
+	b.WriteString("\n")
+	b.WriteString(h("space", "load file or directory"))
+	b.WriteString("\n")
+	b.WriteString(h("bksp/h", "parent directory"))
+	b.WriteString("\n")
+	b.WriteString(h("s q, load PATH", "load from the query bar"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hdr.Render("Report"))
+	b.WriteString("\n")
+	b.WriteString(h("w", "save report to file"))
+	b.WriteString("\n")
+	b.WriteString(h("r", "refresh packet inspector, summary"))
+	b.WriteString("\n")
+	b.WriteString(h("esc", "close report"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hdr.Render("Source"))
+	b.WriteString("\n")
+	b.WriteString(h("ctrl+o", "open diagnostic in source (diagnostics)"))
+	b.WriteString("\n")
+	b.WriteString(h("ctrl+e", "edit in $EDITOR (diagnostics, modules, types)"))
+	b.WriteString("\n")
+	b.WriteString(h("home/end", "top/bottom of file"))
+	b.WriteString("\n")
+	b.WriteString(h("esc", "close source"))
 	b.WriteString("\n\n")
 
 	b.WriteString(h("?", "this help"))
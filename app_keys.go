@@ -18,6 +18,8 @@ func (m model) resolveChord(prefix, key string) (tea.Model, tea.Cmd) {
 		return m.snmpGetNext()
 	case "sw":
 		return m.snmpWalk()
+	case "sr":
+		return m.resumeWalk()
 	case "st":
 		return m.snmpTableData()
 	case "sp":
@@ -25,6 +27,20 @@ func (m model) resolveChord(prefix, key string) (tea.Model, tea.Cmd) {
 	case "sq":
 		m.focus = focusQueryBar
 		return m, m.queryBar.activate()
+	case "sd":
+		return m.snmpDiscover()
+	case "sc":
+		return m.snmpComplianceCheck()
+	case "sa":
+		return m.draftCapabilities()
+	case "si":
+		return m.deviceSummary()
+	case "sf":
+		return m.interfaceDashboard()
+	case "se":
+		return m.physicalInventory()
+	case "sl":
+		return m.readNeighbors()
 
 	// Connection
 	case "cc":
@@ -33,6 +49,8 @@ func (m model) resolveChord(prefix, key string) (tea.Model, tea.Cmd) {
 		return m.snmpDisconnect()
 	case "cs":
 		return m.saveProfile()
+	case "cn":
+		return m.openScanDialog()
 
 	// View switching
 	case "vd":
@@ -75,6 +93,52 @@ func (m model) resolveChord(prefix, key string) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "vc":
 		return m.openColumnPicker()
+	case "vv":
+		return m.conformanceReport()
+	case "vw":
+		return m.wireInspector()
+	case "vn":
+		if m.scanList.prefix != "" {
+			m.bottomPane = bottomScan
+			m.focus = focusResults
+			m.updateLayout()
+		}
+		return m, nil
+	case "vf":
+		return m.showInterfaces()
+	case "ve":
+		if m.inventory.target != "" {
+			m.bottomPane = bottomInventory
+			m.focus = focusResults
+			m.updateLayout()
+		}
+		return m, nil
+	case "vl":
+		return m.showNeighbors()
+	case "vp":
+		if m.topPane == topReport {
+			m.topPane = topDetail
+		} else if m.report.hasContent() {
+			m.topPane = topReport
+			m.focus = focusDetail
+		}
+		return m, nil
+
+	// MIBs
+	case "mr":
+		return m.reloadMibs()
+	case "mw":
+		return m.toggleMibWatch()
+	case "ml":
+		return m.openFilePicker()
+	case "ms":
+		if m.topPane == topSource {
+			m.topPane = topDetail
+			return m, nil
+		}
+		return m.viewNodeSource()
+	case "me":
+		return m.editSelection()
 
 	// Tree pane resize (chord stays active for repeated taps)
 	case "v,":
@@ -113,11 +177,30 @@ func (m model) handleGlobalKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, bool)
 			m.results.walkStatus = "cancelling..."
 			return m, nil, true
 		}
+		if m.tableFetch != nil {
+			m.tableFetch.Cancel()
+			m.setStatus(statusInfo, "Cancelling table fetch...")
+			return m, nil, true
+		}
+		if m.scan != nil {
+			m.scan.Cancel()
+			m.scanList.cancelling = true
+			return m, nil, true
+		}
 		if m.focus == focusWatch {
 			m.focus = focusTree
 			return m, nil, true
 		}
+		if m.ifaces.active && m.bottomPane == bottomInterfaces {
+			m.ifaces.stop()
+			m.focus = focusTree
+			return m, nil, true
+		}
 		if m.focus == focusResults {
+			if m.bottomPane == bottomTableData && m.tableData.isFiltering() {
+				m.tableData.clearFilter()
+				return m, nil, true
+			}
 			if m.results.isFiltering() {
 				m.results.clearFilter()
 				return m, nil, true
@@ -126,11 +209,19 @@ func (m model) handleGlobalKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, bool)
 			return m, nil, true
 		}
 		if m.focus == focusDetail {
+			if m.topPane == topReport || m.topPane == topSource {
+				m.topPane = topDetail
+			}
 			m.focus = focusTree
 			return m, nil, true
 		}
 		return m, nil, true
 	case "/":
+		if m.focus == focusResults && m.bottomPane == bottomTableData {
+			m.focus = focusTableFilter
+			m.tableData.activateFilter()
+			return m, m.tableData.filterInput.Focus(), true
+		}
 		if m.focus == focusResults && m.bottomPane != bottomNone {
 			m.focus = focusResultFilter
 			m.results.activateFilter()
@@ -188,6 +279,11 @@ func (m model) handleGlobalKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, bool)
 		}
 		return m, nil, true
 	case "+", "=":
+		if m.ifaces.active && m.bottomPane == bottomInterfaces {
+			m.ifaces.adjustInterval(watchIntervalStep)
+			m.setStatus(statusInfo, fmt.Sprintf("Interface poll interval: %s", formatInterval(m.ifaces.interval)))
+			return m, clearStatusAfter(statusDisplayDuration), true
+		}
 		if m.watch.active {
 			m.watch.adjustInterval(watchIntervalStep)
 			m.setStatus(statusInfo, fmt.Sprintf("Watch interval: %s", formatInterval(m.watch.interval)))
@@ -195,6 +291,11 @@ func (m model) handleGlobalKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, bool)
 		}
 		return m, nil, true
 	case "-":
+		if m.ifaces.active && m.bottomPane == bottomInterfaces {
+			m.ifaces.adjustInterval(-watchIntervalStep)
+			m.setStatus(statusInfo, fmt.Sprintf("Interface poll interval: %s", formatInterval(m.ifaces.interval)))
+			return m, clearStatusAfter(statusDisplayDuration), true
+		}
 		if m.watch.active {
 			m.watch.adjustInterval(-watchIntervalStep)
 			m.setStatus(statusInfo, fmt.Sprintf("Watch interval: %s", formatInterval(m.watch.interval)))
@@ -269,9 +370,38 @@ func (m model) updateDetail(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	case "ctrl+u", "pgup":
 		m.scrollTopPaneBy(-(m.detail.height / 2))
 	case "home":
-		m.detail.vp.GotoTop()
+		switch m.topPane {
+		case topReport:
+			m.report.vp.GotoTop()
+		case topSource:
+			m.source.vp.GotoTop()
+		default:
+			m.detail.vp.GotoTop()
+		}
 	case "G", "end":
-		m.detail.vp.GotoBottom()
+		switch m.topPane {
+		case topReport:
+			m.report.vp.GotoBottom()
+		case topSource:
+			m.source.vp.GotoBottom()
+		default:
+			m.detail.vp.GotoBottom()
+		}
+	case "w":
+		if m.topPane == topReport {
+			path, err := m.report.save()
+			if err != nil {
+				return m.setStatusReturn(statusError, "Save failed: "+err.Error())
+			}
+			return m.setStatusReturn(statusSuccess, "Report saved: "+path)
+		}
+	case "r":
+		if m.topPane == topReport && m.report.title == wireReportTitle {
+			return m.wireInspector()
+		}
+		if m.topPane == topReport && m.report.title == summaryReportTitle {
+			return m.deviceSummary()
+		}
 	}
 	return m, nil
 }
@@ -422,6 +552,19 @@ func (m model) updateDiag(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
+	case "ctrl+o":
+		diag := m.diag.selectedDiag()
+		if diag == nil || diag.Module == "" {
+			return m, nil
+		}
+		mod := m.mib.Module(diag.Module)
+		if mod == nil {
+			return m.setStatusReturn(statusWarn, "Module not loaded: "+diag.Module)
+		}
+		m.diag.deactivate()
+		return m.openSource(mod, diag.Line, "")
+	case "ctrl+e":
+		return m.editSelection()
 	}
 
 	if handlePaneNav(&m.diag, msg) {
@@ -446,6 +589,8 @@ func (m model) updateModule(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		m.module.toggleExpand()
 		return m, nil
+	case "ctrl+e":
+		return m.editSelection()
 	}
 
 	if handlePaneNav(&m.module, msg) {
@@ -470,6 +615,8 @@ func (m model) updateTypes(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		m.typeBrowser.toggleExpand()
 		return m, nil
+	case "ctrl+e":
+		return m.editSelection()
 	case "tab":
 		m.typeBrowser.cycleTCFilter()
 		return m, nil
@@ -505,6 +652,9 @@ func (m model) updateQueryBar(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 		m.queryBar.deactivate()
 		m.focus = focusTree
+		if qc.op == queryLoad {
+			return m.loadExtraMib(qc.path)
+		}
 		return m.dispatchQuery(*qc)
 	case "tab":
 		m.queryBar.tabComplete()
@@ -520,6 +670,18 @@ func (m model) updateQueryBar(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) updateResults(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch m.bottomPane {
+	case bottomTableData:
+		return m.updateTableData(msg)
+	case bottomScan:
+		return m.updateScan(msg)
+	case bottomInterfaces:
+		return m.updateInterfaces(msg)
+	case bottomInventory:
+		return m.updateInventory(msg)
+	case bottomNeighbors:
+		return m.updateNeighbors(msg)
+	}
 	switch msg.String() {
 	case "j", "down":
 		m.results.cursorDown()
@@ -565,6 +727,43 @@ func (m model) updateResults(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateTableData handles keys for the table data pane, which shares
+// focusResults with the results pane.
+func (m model) updateTableData(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.tableData.lv.CursorDown()
+	case "k", "up":
+		m.tableData.lv.CursorUp()
+	case "ctrl+d", "pgdown":
+		m.tableData.lv.PageDown()
+	case "ctrl+u", "pgup":
+		m.tableData.lv.PageUp()
+	case "home":
+		m.tableData.lv.GoTop()
+	case "G", "end":
+		m.tableData.lv.GoBottom()
+	case "h", "left":
+		m.tableData.columnLeft()
+	case "l", "right":
+		m.tableData.columnRight()
+	case "o":
+		m.tableData.cycleSort()
+		return m.setStatusReturn(statusInfo, "Sort: "+m.tableData.sortLabel())
+	case "r":
+		return m.refreshTableData(false)
+	case "R":
+		return m.refreshTableData(true)
+	case "n":
+		return m.openRowEditor(rowEditCreate)
+	case "e":
+		return m.openRowEditor(rowEditModify)
+	case "D":
+		return m.openRowEditor(rowEditDestroy)
+	}
+	return m, nil
+}
+
 func (m model) updateWatch(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "j", "down":
@@ -583,6 +782,118 @@ func (m model) updateWatch(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateScan handles keys for the scan pane, which shares focusResults
+// with the results pane.
+func (m model) updateScan(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.scanList.lv.CursorDown()
+	case "k", "up":
+		m.scanList.lv.CursorUp()
+	case "ctrl+d", "pgdown":
+		m.scanList.lv.PageDown()
+	case "ctrl+u", "pgup":
+		m.scanList.lv.PageUp()
+	case "home":
+		m.scanList.lv.GoTop()
+	case "G", "end":
+		m.scanList.lv.GoBottom()
+	case "enter":
+		return m.connectScanHit()
+	case "p":
+		return m.saveScanHit()
+	case "r":
+		return m.startScan(m.scanList.prefix, m.scanList.profile)
+	}
+	return m, nil
+}
+
+// updateInterfaces handles keys for the interfaces pane, which shares
+// focusResults with the results pane.
+func (m model) updateInterfaces(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.ifaces.lv.CursorDown()
+	case "k", "up":
+		m.ifaces.lv.CursorUp()
+	case "ctrl+d", "pgdown":
+		m.ifaces.lv.PageDown()
+	case "ctrl+u", "pgup":
+		m.ifaces.lv.PageUp()
+	case "home":
+		m.ifaces.lv.GoTop()
+	case "G", "end":
+		m.ifaces.lv.GoBottom()
+	case "o":
+		m.ifaces.cycleSort()
+		return m.setStatusReturn(statusInfo, "Sort: "+m.ifaces.sortLabel())
+	case "O":
+		m.ifaces.reverseSort()
+		return m.setStatusReturn(statusInfo, "Sort: "+m.ifaces.sortLabel())
+	case "enter":
+		return m.watchInterface()
+	case "r":
+		return m.interfaceDashboard()
+	}
+	return m, nil
+}
+
+// updateInventory handles keys for the inventory pane, which shares
+// focusResults with the results pane.
+func (m model) updateInventory(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.inventory.lv.CursorDown()
+	case "k", "up":
+		m.inventory.lv.CursorUp()
+	case "ctrl+d", "pgdown":
+		m.inventory.lv.PageDown()
+	case "ctrl+u", "pgup":
+		m.inventory.lv.PageUp()
+	case "home":
+		m.inventory.lv.GoTop()
+	case "G", "end":
+		m.inventory.lv.GoBottom()
+	case "enter":
+		m.inventory.toggle()
+	case "l", "right":
+		m.inventory.expand()
+	case "h", "left":
+		m.inventory.collapse()
+	case "r":
+		return m.physicalInventory()
+	}
+	return m, nil
+}
+
+// updateNeighbors handles keys for the neighbors pane, which shares
+// focusResults with the results pane.
+func (m model) updateNeighbors(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.neighbors.lv.CursorDown()
+	case "k", "up":
+		m.neighbors.lv.CursorUp()
+	case "ctrl+d", "pgdown":
+		m.neighbors.lv.PageDown()
+	case "ctrl+u", "pgup":
+		m.neighbors.lv.PageUp()
+	case "home":
+		m.neighbors.lv.GoTop()
+	case "G", "end":
+		m.neighbors.lv.GoBottom()
+	case "enter":
+		return m.hopToNeighbor()
+	case "p":
+		return m.hopWithProfile()
+	case "b":
+		return m.hopBack()
+	case "r":
+		return m.readNeighbors()
+	}
+	return m, nil
+}
+
 func (m model) updateResultFilter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -601,6 +912,9 @@ func (m model) updateResultFilter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.results.deactivateFilter()
 		m.focus = focusResults
 		return m, nil
+	case "ctrl+e":
+		m.results.toggleFilterMode()
+		return m, nil
 	}
 
 	// Forward to text input
@@ -610,6 +924,34 @@ func (m model) updateResultFilter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m model) updateTableFilter(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if m.tableData.filterInput.Value() != "" {
+			// Clear filter, stay in table filter mode
+			m.tableData.clearFilter()
+			return m, nil
+		}
+		m.tableData.deactivateFilter()
+		m.focus = focusResults
+		return m, nil
+	case "enter":
+		m.tableData.deactivateFilter()
+		m.focus = focusResults
+		return m, nil
+	case "tab":
+		m.tableData.tabComplete()
+		m.tableData.recompileFilter()
+		return m, nil
+	}
+
+	m.tableData.tc.reset()
+	var cmd tea.Cmd
+	m.tableData.filterInput, cmd = m.tableData.filterInput.Update(msg)
+	m.tableData.recompileFilter()
+	return m, cmd
+}
+
 func (m model) updateColumnPicker(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -642,6 +984,49 @@ func (m model) updateColumnPicker(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m model) updateFilePicker(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.filePicker.deactivate()
+		m.focus = focusTree
+		m.updateLayout()
+		return m, nil
+	case "enter", "l", "right":
+		path, isDir := m.filePicker.selectedPath()
+		if path == "" {
+			return m, nil
+		}
+		if isDir {
+			m.filePicker.open(path)
+			return m, nil
+		}
+		m.filePicker.deactivate()
+		m.focus = focusTree
+		return m.loadExtraMib(path)
+	case " ":
+		path, _ := m.filePicker.selectedPath()
+		if path == "" {
+			return m, nil
+		}
+		m.filePicker.deactivate()
+		m.focus = focusTree
+		return m.loadExtraMib(path)
+	case "backspace", "h", "left":
+		m.filePicker.parent()
+		return m, nil
+	case "j":
+		m.filePicker.cursorDown()
+		return m, nil
+	case "k":
+		m.filePicker.cursorUp()
+		return m, nil
+	}
+	if handlePaneNav(&m.filePicker, msg) {
+		return m, nil
+	}
+	return m, nil
+}
+
 // columnPickerReturnFocus determines which focus to return to after the picker closes.
 func (m model) columnPickerReturnFocus() focus {
 	switch m.bottomPane {
@@ -78,11 +78,14 @@ func (m *model) applyLayout(l appLayout) {
 	// Top-right sub-pane components
 	m.detail.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy(), m.xrefs)
 	m.tableSchema.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
+	m.report.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
+	m.source.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
 	m.diag.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
 	m.module.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
 	m.typeBrowser.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
 	m.xrefPicker.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
 	m.columnPicker.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
+	m.filePicker.setSize(max(0, l.rightTop.Dx()-panePad), l.rightTop.Dy())
 
 	// Bottom-right sub-pane components
 	botRect := l.rightBot
@@ -93,6 +96,10 @@ func (m *model) applyLayout(l appLayout) {
 	m.results.setSize(max(0, botRect.Dx()-panePad), botRect.Dy())
 	m.tableData.setSize(max(0, botRect.Dx()-panePad), botRect.Dy())
 	m.watch.setSize(max(0, botRect.Dx()-panePad), botRect.Dy())
+	m.scanList.setSize(max(0, botRect.Dx()-panePad), botRect.Dy())
+	m.ifaces.setSize(max(0, botRect.Dx()-panePad), botRect.Dy())
+	m.inventory.setSize(max(0, botRect.Dx()-panePad), botRect.Dy())
+	m.neighbors.setSize(max(0, botRect.Dx()-panePad), botRect.Dy())
 	m.search.setSize(m.width)
 	m.filterBar.setSize(m.width)
 }
@@ -117,6 +124,18 @@ func (m *model) scrollTopPaneBy(n int) {
 		} else {
 			m.tableSchema.scrollUpBy(-n)
 		}
+	case topReport:
+		if n > 0 {
+			m.report.scrollDownBy(n)
+		} else {
+			m.report.scrollUpBy(-n)
+		}
+	case topSource:
+		if n > 0 {
+			m.source.scrollDownBy(n)
+		} else {
+			m.source.scrollUpBy(-n)
+		}
 	default:
 		if n > 0 {
 			m.detail.scrollDownBy(n)
@@ -136,6 +155,14 @@ func (m *model) scrollBottomPaneBy(n int) {
 		m.tableData.lv.CursorBy(n)
 	case bottomWatch:
 		m.watch.lv.CursorBy(n)
+	case bottomScan:
+		m.scanList.lv.CursorBy(n)
+	case bottomInterfaces:
+		m.ifaces.lv.CursorBy(n)
+	case bottomInventory:
+		m.inventory.lv.CursorBy(n)
+	case bottomNeighbors:
+		m.neighbors.lv.CursorBy(n)
 	}
 }
 
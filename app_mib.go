@@ -0,0 +1,388 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// reloadMibs loads the MIB sources again and swaps the result in when it
+// arrives. The current MIBs stay in use until then, and if loading fails.
+func (m model) reloadMibs() (tea.Model, tea.Cmd) {
+	if m.mibReloading {
+		return m.setStatusReturn(statusWarn, "MIB reload in progress")
+	}
+	m.mibReloading = true
+	m.setStatus(statusInfo, "Reloading MIBs...")
+	return m, reloadMibCmd(m.config, m.extraMibs, "")
+}
+
+func (m model) handleMibReload(msg mibReloadMsg) (tea.Model, tea.Cmd) {
+	m.mibReloading = false
+	if msg.added != "" {
+		return m.handleMibLoad(msg)
+	}
+	if msg.err != nil {
+		return m.setStatusReturn(statusError, "MIB reload failed: "+msg.err.Error())
+	}
+	m.applyMib(msg.mib)
+
+	text := fmt.Sprintf("Reloaded %s", m.stats)
+	if m.diag.errors > 0 {
+		text += fmt.Sprintf(", %d errors (v d)", m.diag.errors)
+	}
+	if len(msg.warnings) > 0 {
+		return m.setStatusReturn(statusWarn, text+": "+msg.warnings[0])
+	}
+	if m.diag.errors > 0 {
+		return m.setStatusReturn(statusWarn, text)
+	}
+	return m.setStatusReturn(statusSuccess, text)
+}
+
+// applyMib rebuilds everything derived from the loaded MIBs around nm,
+// keeping the tree selection, expansion, filters and back-navigation
+// stack for names that still exist.
+func (m *model) applyMib(nm *mib.Mib) {
+	var selected *mib.Node
+	if node := m.tree.selectedNode(); node != nil {
+		selected = relocateNode(node, nm)
+		if selected == nil {
+			selected = nm.LongestPrefixByOID(node.OID())
+		}
+	}
+	var nav []*mib.Node
+	for _, node := range m.navStack {
+		if n := relocateNode(node, nm); n != nil {
+			nav = append(nav, n)
+		}
+	}
+
+	m.mib = nm
+	m.tree.setRoot(nm.Root())
+	m.detail.mib = nm
+	m.search.index = buildSearchIndex(nm)
+	if m.search.active {
+		m.search.filter()
+	}
+	m.queryBar.mib = nm
+	m.queryBar.names = completionNames(nm)
+	m.queryBar.resetCompletion()
+	m.xrefs = buildXrefMap(nm)
+	m.xrefPicker.mib = nm
+	m.results.mib = nm
+	m.tableData.mib = nm
+	if m.results.treeMode {
+		m.results.rebuildTree()
+	}
+	m.moduleFirstNode = buildModuleFirstNode(nm)
+	m.stats = fmt.Sprintf("%d modules, %d nodes", len(nm.Modules()), nm.NodeCount())
+
+	diag := newDiagModel(nm)
+	diag.input = m.diag.input
+	diag.severity = m.diag.severity
+	diag.applyFilter()
+	m.diag = diag
+
+	mod := newModuleModel(nm)
+	mod.input = m.module.input
+	mod.applyFilter()
+	m.module = mod
+
+	types := newTypeModel(nm)
+	types.input = m.typeBrowser.input
+	types.showTC = m.typeBrowser.showTC
+	types.applyFilter()
+	m.typeBrowser = types
+
+	if m.source.hasContent() {
+		if content, err := readMibSource(m.source.path); err == nil {
+			m.source.refresh(content, nm.Diagnostics())
+		}
+	}
+
+	m.rebindTableViews(nm)
+	if check := m.compliance; check != nil {
+		// The walk in flight keeps its label; its result is reported
+		// against the compliance as reloaded, or dropped if it is gone.
+		m.compliance = nil
+		if node := relocateNode(check.comp.Node(), nm); node != nil && node.Compliance() != nil {
+			rebuilt := newComplianceCheck(node.Compliance(), nm)
+			rebuilt.label = check.label
+			m.compliance = rebuilt
+		}
+	}
+	// Reports (compliance, capabilities, summary) are kept: they are
+	// plain text and hold nothing from the old MIBs.
+
+	m.navStack = nav
+	if selected != nil {
+		m.tree.jumpToNode(selected)
+	}
+	m.updateLayout()
+	m.syncSelection()
+}
+
+// rebindTableViews points the table schema pane, the table data pane and
+// the row editor at the reloaded MIBs. The row editor is closed, since its
+// fields were built from the old objects. A table fetch in flight is
+// cancelled and the rows already shown are kept; a table that no longer
+// exists can't be refreshed or edited.
+func (m *model) rebindTableViews(nm *mib.Mib) {
+	m.tableSchema.table = nil // resolved again by syncSelection
+	if m.rowEditor != nil {
+		m.rowEditor = nil
+		if m.overlay.kind == overlayRowEdit {
+			m.overlay.kind = overlayNone
+		}
+	}
+	if m.tableDataObj == nil {
+		return
+	}
+	if m.tableFetch != nil {
+		m.tableFetch.Cancel()
+	}
+	var tbl *mib.Object
+	if node := relocateNode(m.tableDataObj.Node(), nm); node != nil {
+		if obj := node.Object(); obj != nil && obj.IsTable() {
+			tbl = obj
+		}
+	}
+	m.tableDataObj = tbl
+}
+
+// mibSourcePaths returns the -p paths and the sources loaded at runtime,
+// which together are what a watch polls.
+func (m model) mibSourcePaths() []string {
+	return append(slices.Clone(m.config.mibPaths), m.extraMibs...)
+}
+
+// toggleMibWatch starts or stops watching the MIB paths for changes.
+func (m model) toggleMibWatch() (tea.Model, tea.Cmd) {
+	paths := m.mibSourcePaths()
+	if len(paths) == 0 {
+		return m.setStatusReturn(statusWarn, "Watching needs -p paths or loaded MIBs; system MIB locations are not watched")
+	}
+	if m.mibWatch.active {
+		m.mibWatch.stop()
+		return m.setStatusReturn(statusInfo, "Stopped watching MIB paths")
+	}
+	cmd := m.mibWatch.start(paths)
+	ret, statusCmd := m.setStatusReturn(statusSuccess, "Watching MIB paths for changes")
+	return ret, tea.Batch(statusCmd, cmd)
+}
+
+func (m model) handleMibWatchTick(msg mibWatchTickMsg) (tea.Model, tea.Cmd) {
+	if !m.mibWatch.active || msg.seq != m.mibWatch.seq {
+		return m, nil // stale tick
+	}
+	next := m.mibWatch.tickCmd(m.mibSourcePaths())
+	if msg.stamp == m.mibWatch.stamp || m.mibReloading {
+		return m, next
+	}
+	m.mibWatch.stamp = msg.stamp
+	ret, cmd := m.reloadMibs()
+	return ret, tea.Batch(cmd, next)
+}
+
+// openFilePicker shows the file picker for loading extra MIBs, starting in
+// the first -p path or the working directory.
+func (m model) openFilePicker() (tea.Model, tea.Cmd) {
+	dir := "."
+	if len(m.config.mibPaths) > 0 {
+		dir = m.config.mibPaths[0]
+	}
+	m.filePicker.activate(dir)
+	m.focus = focusFilePicker
+	m.updateLayout()
+	return m, nil
+}
+
+// loadExtraMib adds a MIB file or directory to the loaded set. Everything is
+// loaded again with the new source first, and the modules it provided are
+// reported when the result arrives.
+func (m model) loadExtraMib(path string) (tea.Model, tea.Cmd) {
+	if m.mibReloading {
+		return m.setStatusReturn(statusWarn, "MIB reload in progress")
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if _, err := os.Stat(path); err != nil {
+		return m.setStatusReturn(statusError, "Cannot load MIBs: "+err.Error())
+	}
+	if slices.Contains(m.extraMibs, path) {
+		return m.setStatusReturn(statusWarn, path+" is already loaded (m r reloads it)")
+	}
+	extra := append([]string{path}, m.extraMibs...)
+	m.mibReloading = true
+	m.setStatus(statusInfo, "Loading "+path+"...")
+	return m, reloadMibCmd(m.config, extra, path)
+}
+
+// handleMibLoad applies a load that added a source, and opens a report of
+// the modules the source provided and their diagnostics.
+func (m model) handleMibLoad(msg mibReloadMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setStatusReturn(statusError, "Cannot load "+msg.added+": "+msg.err.Error())
+	}
+	var added []*mib.Module
+	names := make(map[string]bool)
+	for _, mod := range msg.mib.Modules() {
+		if withinSource(mod.SourcePath(), msg.added) {
+			added = append(added, mod)
+			names[mod.Name()] = true
+		}
+	}
+	if len(added) == 0 {
+		if len(msg.warnings) > 0 {
+			return m.setStatusReturn(statusWarn, msg.warnings[0])
+		}
+		return m.setStatusReturn(statusWarn, "No MIB modules found in "+msg.added)
+	}
+
+	prev := make(map[string]bool)
+	for _, mod := range m.mib.Modules() {
+		prev[mod.Name()] = true
+	}
+	m.extraMibs = append([]string{msg.added}, m.extraMibs...)
+	m.applyMib(msg.mib)
+
+	var watchCmd tea.Cmd
+	if m.mibWatch.active {
+		watchCmd = m.mibWatch.start(m.mibSourcePaths())
+	}
+
+	lines := buildMibLoadReport(msg.added, added, prev, msg.mib.Diagnostics())
+	m.report.set("Loaded MIBs: "+filepath.Base(msg.added), mibLoadReportFile(msg.added), lines)
+	m.topPane = topReport
+	m.focus = focusDetail
+	m.updateLayout()
+
+	var errs int
+	for _, d := range msg.mib.Diagnostics() {
+		if names[d.Module] && d.Severity <= mib.SeverityError {
+			errs++
+		}
+	}
+	typ, text := statusSuccess, fmt.Sprintf("Loaded %d modules from %s", len(added), filepath.Base(msg.added))
+	if errs > 0 {
+		typ = statusWarn
+		text += fmt.Sprintf(", %d errors", errs)
+	}
+	ret, statusCmd := m.setStatusReturn(typ, text)
+	return ret, tea.Batch(statusCmd, watchCmd)
+}
+
+// viewNodeSource shows the source of the selected tree node's module,
+// opened at the node's definition.
+func (m model) viewNodeSource() (tea.Model, tea.Cmd) {
+	node := m.tree.selectedNode()
+	if node == nil {
+		return m, nil
+	}
+	mod := node.Module()
+	if mod == nil {
+		return m.setStatusReturn(statusWarn, "No module defines "+nodeLabel(node))
+	}
+	return m.openSource(mod, 0, node.Name())
+}
+
+// openSource shows a module's source file in the top-right pane at line,
+// or at the definition of name when line is 0.
+func (m model) openSource(mod *mib.Module, line int, name string) (tea.Model, tea.Cmd) {
+	path := mod.SourcePath()
+	if path == "" {
+		return m.setStatusReturn(statusWarn, "No source file for "+mod.Name())
+	}
+	content, err := readMibSource(path)
+	if err != nil {
+		return m.setStatusReturn(statusError, "Read source: "+err.Error())
+	}
+	if line == 0 {
+		line = definitionLine(content, name)
+	}
+	m.source.open(path, mod.Name(), content, line, m.mib.Diagnostics())
+	m.topPane = topSource
+	m.focus = focusDetail
+	m.updateLayout()
+	return m, nil
+}
+
+// editSelection opens the definition of whatever is selected in the focused
+// pane in the external editor: a type, module or diagnostic in those panes,
+// the line opened in the source viewer, or the tree node otherwise.
+func (m model) editSelection() (tea.Model, tea.Cmd) {
+	switch {
+	case m.focus == focusDiag:
+		if diag := m.diag.selectedDiag(); diag != nil {
+			if mod := m.mib.Module(diag.Module); mod != nil {
+				return m.editSource(mod, diag.Line, "")
+			}
+		}
+	case m.focus == focusTypes:
+		if t := m.typeBrowser.selectedType(); t != nil && t.Module() != nil {
+			return m.editSource(t.Module(), 0, t.Name())
+		}
+	case m.focus == focusModule:
+		if mod := m.module.selectedModule(); mod != nil {
+			return m.editSource(mod, 0, mod.Name())
+		}
+	case m.topPane == topSource && m.focus == focusDetail:
+		if mod := m.mib.Module(m.source.module); mod != nil {
+			return m.editSource(mod, m.source.target, "")
+		}
+	default:
+		if node := m.tree.selectedNode(); node != nil && node.Module() != nil {
+			return m.editSource(node.Module(), 0, node.Name())
+		}
+	}
+	return m.setStatusReturn(statusWarn, "Nothing to edit here")
+}
+
+// editSource suspends the UI and opens a module's source file in the
+// external editor at line, or at the definition of name when line is 0.
+func (m model) editSource(mod *mib.Module, line int, name string) (tea.Model, tea.Cmd) {
+	path := mod.SourcePath()
+	if path == "" {
+		return m.setStatusReturn(statusWarn, "No source file for "+mod.Name())
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if _, aerr := readMibSource(path); aerr == nil {
+			return m.setStatusReturn(statusWarn, mod.Name()+" is inside an archive; extract it to edit")
+		}
+		return m.setStatusReturn(statusError, "Read source: "+err.Error())
+	}
+	if line == 0 {
+		line = definitionLine(content, name)
+	}
+	stamp := mibPathsStamp([]string{path})
+	return m, tea.ExecProcess(editorCommand(path, line), func(err error) tea.Msg {
+		return mibEditMsg{path: path, module: mod.Name(), stamp: stamp, err: err}
+	})
+}
+
+// handleMibEdit reloads the MIBs once the editor exits, if the file changed.
+// A running watch picks the change up by itself.
+func (m model) handleMibEdit(msg mibEditMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setStatusReturn(statusError, "Editor: "+msg.err.Error())
+	}
+	if mibPathsStamp([]string{msg.path}) == msg.stamp {
+		return m.setStatusReturn(statusInfo, msg.module+" unchanged")
+	}
+	if m.mibWatch.active {
+		return m.setStatusReturn(statusInfo, msg.module+" changed, reloading on the next watch check")
+	}
+	if m.mibReloading {
+		return m.setStatusReturn(statusWarn, msg.module+" changed during a MIB reload (m r reloads it again)")
+	}
+	m.mibReloading = true
+	m.setStatus(statusInfo, msg.module+" changed, reloading MIBs...")
+	return m, reloadMibCmd(m.config, m.extraMibs, "")
+}
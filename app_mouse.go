@@ -110,10 +110,7 @@ func (m *model) handleHeaderClick(msg tea.MouseClickMsg, l appLayout) {
 	badgeW := lipgloss.Width(badge)
 
 	var rightW int
-	if m.snmp.IsConnected() {
-		pills := styles.Status.SuccessIcon.Render(IconPending) + " " +
-			styles.Pill.Connected.Render(m.snmp.Target) + " " +
-			styles.Pill.Version.Render("("+m.snmp.Version+")")
+	if pills := m.devicePills(); pills != "" {
 		rightW = lipgloss.Width(badge + "   " + pills)
 	} else {
 		statsText := styles.Label.Render(m.stats)
@@ -176,6 +173,18 @@ func (m *model) handleBottomPaneClick(msg tea.MouseClickMsg, l appLayout) {
 	case bottomWatch:
 		row := msg.Y - l.rightBot.Min.Y - watchHeaderLines + m.watch.lv.Offset()
 		m.watch.clickRow(row)
+	case bottomScan:
+		row := msg.Y - l.rightBot.Min.Y - scanHeaderLines + m.scanList.lv.Offset()
+		m.scanList.clickRow(row)
+	case bottomInterfaces:
+		row := msg.Y - l.rightBot.Min.Y - ifaceHeaderLines + m.ifaces.lv.Offset()
+		m.ifaces.clickRow(row)
+	case bottomInventory:
+		row := msg.Y - l.rightBot.Min.Y - inventoryHeaderLines + m.inventory.lv.Offset()
+		m.inventory.clickRow(row)
+	case bottomNeighbors:
+		row := msg.Y - l.rightBot.Min.Y - neighborHeaderLines + m.neighbors.lv.Offset()
+		m.neighbors.clickRow(row)
 	}
 }
 
@@ -277,3 +286,21 @@ func (m model) handleDialogClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd) {
 	}
 	return m, nil
 }
+
+func (m model) handleRowEditorClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd) {
+	if msg.Button != tea.MouseLeft || m.rowEditor == nil {
+		return m, nil
+	}
+
+	// Reconstruct the dialog box rect (same logic as drawCentered)
+	l := m.cachedLayout
+	box := styles.Dialog.Box.Render(m.rowEditor.view())
+	rect := centerRect(l.area, lipgloss.Width(box), lipgloss.Height(box))
+
+	// Dialog.Box has RoundedBorder (1 row) + Padding(1, 2) = 2 rows top offset
+	contentY := msg.Y - rect.Min.Y - 2
+	if fi := m.rowEditor.lineToField(contentY); fi >= 0 {
+		return m, m.rowEditor.focusFieldAt(fi)
+	}
+	return m, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/golangsnmp/gomib/mib"
@@ -12,20 +13,49 @@ import (
 	"github.com/gosnmp/gosnmp"
 )
 
-// requireConnectedIdle checks that the SNMP session is connected and no walk is
-// in progress. Returns false with an appropriate status message if either check fails.
+// requireConnectedIdle checks that the SNMP session is connected and no walk or
+// table fetch is in progress. Returns false with an appropriate status message if either check fails.
 func (m model) requireConnectedIdle() (tea.Model, tea.Cmd, bool) {
 	if !m.snmp.IsConnected() {
 		ret, cmd := m.setStatusReturn(statusError, "Not connected")
 		return ret, cmd, false
 	}
-	if m.walk != nil {
-		ret, cmd := m.setStatusReturn(statusWarn, "Walk in progress")
+	if busy := m.sessionBusy(); busy != "" {
+		ret, cmd := m.setStatusReturn(statusWarn, busy)
 		return ret, cmd, false
 	}
 	return m, nil, true
 }
 
+// sessionBusy describes the operation holding the session, or returns ""
+// when none is. The session serializes requests, but one operation at a
+// time keeps results and progress from interleaving.
+func (m model) sessionBusy() string {
+	switch {
+	case m.walk != nil:
+		return "Walk in progress"
+	case m.tableFetch != nil:
+		return "Table fetch in progress"
+	case m.discovering:
+		return "Discovery in progress"
+	case m.identifying:
+		return "Identifying the device"
+	case m.summary != nil:
+		return "Device summary in progress"
+	case m.compliance != nil:
+		return "Compliance check in progress"
+	case m.inventory.loading:
+		return "Inventory in progress"
+	case m.neighbors.loading:
+		return "Neighbor discovery in progress"
+	case m.ifaces.polling:
+		return "Interface poll in progress"
+	case m.setting:
+		return "SET in progress"
+	}
+	return ""
+}
+
 // selectedOID holds the node and OID returned by requireSelectedOID.
 type selectedOID struct {
 	node *mib.Node
@@ -62,6 +92,400 @@ func (m model) openConnectDialog() (tea.Model, tea.Cmd) {
 	return m, d.focusCmd()
 }
 
+// openScanDialog opens the connect dialog in subnet sweep mode, with the
+// last connection's credentials filled in.
+func (m model) openScanDialog() (tea.Model, tea.Cmd) {
+	if m.scan != nil {
+		return m.setStatusReturn(statusWarn, "Scan in progress")
+	}
+	d := newScanDialog(m.config, m.lastDevice)
+	m.dialog = &d
+	m.overlay.kind = overlayConnect
+	return m, d.focusCmd()
+}
+
+// startScan sweeps prefix for agents and shows the scan pane.
+func (m model) startScan(prefix string, p snmp.Profile) (tea.Model, tea.Cmd) {
+	if m.scan != nil {
+		return m.setStatusReturn(statusWarn, "Scan in progress")
+	}
+	ss, cmd, err := snmp.StartScanCmd(p, prefix, m.mib)
+	if err != nil {
+		return m.setStatusReturn(statusError, "Scan: "+err.Error())
+	}
+	m.scan = ss
+	m.scanList.start(ss.Prefix, p, ss.Total)
+	m.bottomPane = bottomScan
+	m.focus = focusResults
+	m.updateLayout()
+	return m, cmd
+}
+
+func (m model) handleScanBatch(msg snmp.ScanBatchMsg) (tea.Model, tea.Cmd) {
+	if m.scan == nil {
+		return m, nil
+	}
+	m.scanList.add(msg.Hits, msg.Probed)
+	if !msg.Done {
+		return m, snmp.WaitScanCmd(m.scan.Ch)
+	}
+
+	m.scan = nil
+	m.scanList.finish()
+	found := fmt.Sprintf("SCAN %s: %d agents", m.scanList.prefix, len(m.scanList.hits))
+	if msg.Cancelled {
+		return m.setStatusReturn(statusInfo, fmt.Sprintf("%s (cancelled after %d/%d)", found, m.scanList.probed, m.scanList.total))
+	}
+	return m.setStatusReturn(statusSuccess, found)
+}
+
+// scanDevice builds a profile for an agent found by a sweep, named by its
+// sysName unless a saved profile for another target already has that name.
+func (m model) scanDevice(hit snmp.ScanHit) profile.Device {
+	p := m.scanList.profile
+	p.Target = hit.Target()
+	name := hit.Name
+	if name == "" {
+		name = p.Target
+	}
+	if m.profiles != nil {
+		for _, d := range m.profiles.Devices() {
+			if d.Name == name && d.Target != p.Target {
+				name += " (" + p.Target + ")"
+				break
+			}
+		}
+	}
+	return profile.Device{Name: name, Profile: p}
+}
+
+// connectScanHit connects to the agent selected in the scan pane,
+// replacing the current session.
+func (m model) connectScanHit() (tea.Model, tea.Cmd) {
+	hit := m.scanList.selected()
+	if hit == nil {
+		return m, nil
+	}
+	return m.switchDevice(m.scanDevice(*hit))
+}
+
+// switchDevice closes the current session and connects to dev.
+func (m model) switchDevice(dev profile.Device) (tea.Model, tea.Cmd) {
+	if m.walk != nil {
+		return m.setStatusReturn(statusWarn, "Walk in progress")
+	}
+	if m.tableFetch != nil {
+		return m.setStatusReturn(statusWarn, "Table fetch in progress")
+	}
+	if m.watch.active {
+		m.watch.stop()
+	}
+	m.snmp.Close()
+	m.lastDevice = dev
+	m.setStatus(statusInfo, "Connecting to "+dev.Target+"...")
+	return m, snmp.ConnectCmd(dev.Profile)
+}
+
+// saveScanHit saves the agent selected in the scan pane as a profile.
+func (m model) saveScanHit() (tea.Model, tea.Cmd) {
+	hit := m.scanList.selected()
+	if hit == nil {
+		return m, nil
+	}
+	if m.profiles == nil {
+		return m, nil
+	}
+	dev := m.scanDevice(*hit)
+	m.profiles.Upsert(dev)
+	if err := m.profiles.Save(); err != nil {
+		return m.setStatusReturn(statusError, "Save failed: "+err.Error())
+	}
+	return m.setStatusReturn(statusSuccess, "Profile saved: "+dev.Name)
+}
+
+// handleIdentify records the device identity read after connecting and
+// points out vendor modules that would name the product, then collects
+// the device summary.
+func (m model) handleIdentify(msg snmp.IdentifyMsg) (tea.Model, tea.Cmd) {
+	if !m.snmp.IsConnected() || m.snmp.Target != msg.Target {
+		return m, nil // stale: reconnected or disconnected since
+	}
+	m.identifying = false
+	if msg.Err != nil {
+		ret, cmd := m.setStatusReturn(statusWarn, errorStatus("sysObjectID", msg.Err, m.mib))
+		return ret, tea.Batch(cmd, m.followNeighbors())
+	}
+	if msg.ObjectID == "" {
+		return m.startSummary(false)
+	}
+	m.identity = identifyDevice(msg.ObjectID, m.mib)
+	text := "Connected to " + m.identity.label()
+	if hint := m.identity.suggestion(); hint != "" {
+		m.setStatus(statusInfo, text+": "+hint)
+	} else {
+		m.setStatus(statusSuccess, text)
+	}
+	ret, cmd := m.startSummary(false)
+	return ret, tea.Batch(clearStatusAfter(statusDisplayDuration), cmd)
+}
+
+// deviceSummary collects the system group, interface states, inventory
+// and host resources, and shows them as a report.
+func (m model) deviceSummary() (tea.Model, tea.Cmd) {
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+	m.setStatus(statusInfo, "Collecting device summary...")
+	return m.startSummary(true)
+}
+
+// startSummary begins collecting the device summary. One started on
+// connect is shown only if the top pane is free for it.
+func (m model) startSummary(focus bool) (tea.Model, tea.Cmd) {
+	m.summary = &summaryRequest{label: "summary " + m.snmp.Target, focus: focus}
+	return m, snmp.CollectCmd(m.snmp, m.summary.label, summaryOIDs(), summaryValueLimit)
+}
+
+func (m model) handleSummaryResult(msg snmp.CollectMsg) (tea.Model, tea.Cmd) {
+	req := m.summary
+	m.summary = nil
+	if m.snmp == nil {
+		return m, nil // disconnected
+	}
+
+	target := m.snmp.Target
+	lines := buildSummaryReport(target, m.identity, msg.PDUs, msg.Err, m.mib)
+	showingSummary := m.topPane == topReport && m.report.title == summaryReportTitle
+	if !req.focus && m.topPane != topDetail && !showingSummary {
+		return m, nil // the user has another view open
+	}
+	m.report.set(summaryReportTitle, summaryReportFile(target), lines)
+	m.topPane = topReport
+	if req.focus {
+		m.focus = focusDetail
+		if msg.Err != nil {
+			return m.setStatusReturn(statusError, errorStatus("Summary incomplete", msg.Err, m.mib))
+		}
+		return m.setStatusReturn(statusSuccess, "Device summary: done (w to save)")
+	}
+	return m, nil
+}
+
+// interfaceDashboard starts polling the agent's ifTable and ifXTable into
+// the interfaces pane.
+func (m model) interfaceDashboard() (tea.Model, tea.Cmd) {
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+	if m.watch.active {
+		m.watch.stop()
+	}
+
+	m.ifaces.start(m.snmp.Target)
+	m.bottomPane = bottomInterfaces
+	m.focus = focusResults
+	m.updateLayout()
+
+	m.setStatus(statusInfo, "Polling interfaces...")
+	return m, tea.Batch(clearStatusAfter(statusDisplayDuration), m.ifaces.startPollCmd(m.snmp))
+}
+
+// showInterfaces brings back the interfaces pane, resuming polling if it
+// was paused and the session is still on the same agent and idle.
+func (m model) showInterfaces() (tea.Model, tea.Cmd) {
+	if m.ifaces.target == "" {
+		return m, nil
+	}
+	m.bottomPane = bottomInterfaces
+	m.focus = focusResults
+	m.updateLayout()
+
+	if m.ifaces.active || m.walk != nil || m.tableFetch != nil ||
+		!m.snmp.IsConnected() || m.snmp.Target != m.ifaces.target {
+		return m, nil
+	}
+	if m.watch.active {
+		m.watch.stop()
+	}
+	m.ifaces.resume()
+	return m, m.ifaces.startPollCmd(m.snmp)
+}
+
+func (m model) handleInterfacePoll(msg snmp.CollectMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.ifaces.polling = false
+		m.setStatus(statusError, errorStatus("Interface poll failed", msg.Err, m.mib))
+		return m, tea.Batch(clearStatusAfter(statusDisplayDuration), m.ifaces.scheduleNextTick())
+	}
+	m.ifaces.handlePoll(msg.PDUs, msg.At, m.mib)
+	return m, m.ifaces.scheduleNextTick()
+}
+
+// watchInterface opens a watch on the status and counters of the
+// interface selected in the interfaces pane, pausing the dashboard.
+func (m model) watchInterface() (tea.Model, tea.Cmd) {
+	r := m.ifaces.selected()
+	if r == nil {
+		return m, nil
+	}
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+
+	name := r.name
+	if name == "" {
+		name = "ifIndex " + r.suffix
+	}
+	m.ifaces.stop()
+	m.watch.startInstances(name, m.ifaces.counterOIDs(*r))
+	m.bottomPane = bottomWatch
+	m.focus = focusWatch
+	m.updateLayout()
+
+	m.setStatus(statusInfo, "WATCH "+name+"...")
+	return m, m.watch.startPollCmd(m.snmp)
+}
+
+// physicalInventory collects entPhysicalTable and any entity sensors and
+// shows them as a containment tree in the inventory pane.
+func (m model) physicalInventory() (tea.Model, tea.Cmd) {
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+	if m.watch.active {
+		m.watch.stop()
+	}
+
+	m.inventory.start(m.snmp.Target)
+	m.bottomPane = bottomInventory
+	m.focus = focusResults
+	m.updateLayout()
+
+	m.setStatus(statusInfo, "Reading physical inventory...")
+	return m, snmp.CollectCmd(m.snmp, m.inventory.label, inventoryOIDs(), 0)
+}
+
+func (m model) handleInventoryResult(msg snmp.CollectMsg) (tea.Model, tea.Cmd) {
+	m.inventory.load(msg.PDUs, msg.Err, m.mib)
+	if msg.Err != nil {
+		return m.setStatusReturn(statusError, errorStatus("Inventory incomplete", msg.Err, m.mib))
+	}
+	return m.setStatusReturn(statusSuccess, fmt.Sprintf("Inventory: %d entities, %d sensors",
+		m.inventory.count, m.inventory.sensors))
+}
+
+// readNeighbors collects the LLDP remote tables, and the CDP cache when
+// CISCO-CDP-MIB is loaded, into the neighbors pane.
+func (m model) readNeighbors() (tea.Model, tea.Cmd) {
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+	if m.watch.active {
+		m.watch.stop()
+	}
+
+	cmd := m.startNeighbors()
+	m.bottomPane = bottomNeighbors
+	m.focus = focusResults
+	m.updateLayout()
+
+	m.setStatus(statusInfo, "Reading neighbors...")
+	return m, cmd
+}
+
+func (m *model) startNeighbors() tea.Cmd {
+	cdp := cdpLoaded(m.mib)
+	m.neighbors.start(m.snmp.Target, cdp)
+	return snmp.CollectCmd(m.snmp, m.neighbors.label, neighborOIDs(cdp), 0)
+}
+
+// followNeighbors collects the neighbors of a device reached by a hop.
+// It waits for the connect-time identify and summary so the collection
+// does not share the session with them.
+func (m *model) followNeighbors() tea.Cmd {
+	if !m.neighbors.follow || m.snmp == nil {
+		return nil
+	}
+	m.neighbors.follow = false
+	return snmp.CollectCmd(m.snmp, m.neighbors.label, neighborOIDs(m.neighbors.cdp), 0)
+}
+
+func (m model) handleNeighborResult(msg snmp.CollectMsg) (tea.Model, tea.Cmd) {
+	m.neighbors.load(msg.PDUs, msg.Err, m.mib)
+	if msg.Err != nil {
+		return m.setStatusReturn(statusError, errorStatus("Neighbors incomplete", msg.Err, m.mib))
+	}
+	return m.setStatusReturn(statusSuccess, fmt.Sprintf("Neighbors: %d", len(m.neighbors.neighbors)))
+}
+
+// showNeighbors brings back the neighbors pane.
+func (m model) showNeighbors() (tea.Model, tea.Cmd) {
+	if m.neighbors.target == "" {
+		return m, nil
+	}
+	m.bottomPane = bottomNeighbors
+	m.focus = focusResults
+	m.updateLayout()
+	return m, nil
+}
+
+// hopTarget returns the selected neighbor and the address to connect to
+// it on, or ok=false with a status explaining why there is none.
+func (m model) hopTarget() (*neighbor, tea.Model, tea.Cmd, bool) {
+	n := m.neighbors.selected()
+	if n == nil {
+		return nil, m, nil, false
+	}
+	if !n.addr.IsValid() {
+		ret, cmd := m.setStatusReturn(statusWarn, n.name+" advertises no management address")
+		return nil, ret, cmd, false
+	}
+	return n, m, nil, true
+}
+
+// hopToNeighbor connects to the selected neighbor with the credentials of
+// the current connection.
+func (m model) hopToNeighbor() (tea.Model, tea.Cmd) {
+	n, ret, cmd, ok := m.hopTarget()
+	if !ok {
+		return ret, cmd
+	}
+	dev := m.lastDevice
+	dev.Name = n.name
+	dev.Target = n.addr.String()
+	m.neighbors.pending = dev.Target
+	return m.switchDevice(dev)
+}
+
+// hopWithProfile opens the connect dialog for the selected neighbor, so a
+// saved profile or other credentials can be used for it.
+func (m model) hopWithProfile() (tea.Model, tea.Cmd) {
+	n, ret, cmd, ok := m.hopTarget()
+	if !ok {
+		return ret, cmd
+	}
+	var profiles []profile.Device
+	if m.profiles != nil {
+		profiles = m.profiles.Devices()
+	}
+	target := n.addr.String()
+	d := newHopDialog(m.config, profiles, m.lastDevice, n.name, target)
+	m.dialog = &d
+	m.overlay.kind = overlayConnect
+	m.neighbors.pending = target
+	return m, d.focusCmd()
+}
+
+// hopBack reconnects to the device before the current one on the path.
+func (m model) hopBack() (tea.Model, tea.Cmd) {
+	dev, ok := m.neighbors.previous()
+	if !ok {
+		return m.setStatusReturn(statusWarn, "No earlier hop")
+	}
+	m.neighbors.pending = dev.Target
+	return m.switchDevice(dev)
+}
+
 // snmpWatch starts periodic polling of the selected tree node's subtree.
 func (m model) snmpWatch() (tea.Model, tea.Cmd) {
 	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
@@ -173,7 +597,61 @@ func (m model) snmpTableData() (tea.Model, tea.Cmd) {
 
 	m.setStatus(statusInfo, "Fetching "+tbl.Name()+"...")
 
-	return m, snmp.TableWalkCmd(m.snmp, tbl, m.mib)
+	return m.startTableFetch(nil)
+}
+
+// refreshTableData re-fetches the table currently shown in the table data
+// pane. With visibleOnly, only the columns the column picker leaves visible
+// are fetched.
+func (m model) refreshTableData(visibleOnly bool) (tea.Model, tea.Cmd) {
+	if m.tableDataObj == nil {
+		return m, nil
+	}
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+	var only []string
+	if visibleOnly {
+		only = m.tableData.fetchColumns()
+	}
+	m.tableData.setRefreshing()
+	return m.startTableFetch(only)
+}
+
+// startTableFetch begins a column-wise fetch of the table data pane's table,
+// walking only the named columns when only is non-nil.
+func (m model) startTableFetch(only []string) (tea.Model, tea.Cmd) {
+	tf, cmd := snmp.StartTableFetchCmd(m.snmp, m.tableDataObj, only, m.mib)
+	m.tableFetch = tf
+	m.tableData.beginFetch(m.tableDataObj, tf)
+	return m, cmd
+}
+
+// openRowEditor opens the row editor dialog for the table data pane. Modify
+// and destroy act on the row under the cursor.
+func (m model) openRowEditor(mode rowEditMode) (tea.Model, tea.Cmd) {
+	if m.bottomPane != bottomTableData || m.tableDataObj == nil {
+		return m, nil
+	}
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+
+	var row *snmp.TableRow
+	if mode != rowEditCreate {
+		row = m.tableData.selectedRow()
+		if row == nil {
+			return m.setStatusReturn(statusWarn, "No row selected")
+		}
+	}
+
+	d, err := newRowEditor(mode, m.tableDataObj, row, m.mib)
+	if err != nil {
+		return m.setStatusReturn(statusWarn, m.tableDataObj.Name()+": "+err.Error())
+	}
+	m.rowEditor = d
+	m.overlay.kind = overlayRowEdit
+	return m, d.focusCmd()
 }
 
 func (m model) snmpDisconnect() (tea.Model, tea.Cmd) {
@@ -204,7 +682,7 @@ func (m model) startQueryWalk(oidStr string) (tea.Model, tea.Cmd) {
 // to the results pane. Used by both snmpWalk (tree-based) and startQueryWalk
 // (query bar-based).
 func (m model) startWalk(oidStr, label string, walkOID mib.OID) (tea.Model, tea.Cmd) {
-	ws, cmd := snmp.StartWalkCmd(m.snmp, oidStr)
+	ws, cmd := snmp.StartWalkCmd(m.snmp, oidStr, "")
 	m.walk = ws
 
 	g := snmp.ResultGroup{
@@ -222,6 +700,33 @@ func (m model) startWalk(oidStr, label string, walkOID mib.OID) (tea.Model, tea.
 	return m, cmd
 }
 
+// resumeWalk continues the interrupted walk shown in the results pane from
+// the last instance it received, appending to the same result group.
+func (m model) resumeWalk() (tea.Model, tea.Cmd) {
+	if ret, cmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, cmd
+	}
+	g := m.results.history.Current()
+	if g == nil || g.Op != snmp.OpWalk || !g.Partial || g.WalkRootOID == nil {
+		return m.setStatusReturn(statusWarn, "No interrupted walk to resume")
+	}
+	from := ""
+	if len(g.Results) > 0 {
+		from = g.Results[len(g.Results)-1].OID
+	}
+	ws, cmd := snmp.StartWalkCmd(m.snmp, g.WalkRootOID.String(), from)
+	m.walk = ws
+	g.Err = nil
+	g.Partial = false
+	m.results.walkStatus = "resuming..."
+	m.bottomPane = bottomResults
+	m.focus = focusResults
+	m.updateLayout()
+
+	m.setStatus(statusInfo, fmt.Sprintf("%s: resuming after %d results...", g.Label, len(g.Results)))
+	return m, cmd
+}
+
 // dispatchQuery executes a parsed query bar command.
 func (m model) dispatchQuery(cmd queryCmd) (tea.Model, tea.Cmd) {
 	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
@@ -268,7 +773,7 @@ func (m model) handleGetResult(msg snmp.GetMsg) (tea.Model, tea.Cmd) {
 	g := m.handleSNMPResult(snmp.OpGet, label, msg.Results, msg.Err)
 
 	if msg.Err != nil {
-		return m.setStatusReturn(statusError, "GET failed: "+msg.Err.Error())
+		return m.setStatusReturn(statusError, errorStatus("GET failed", msg.Err, m.mib))
 	}
 	return m.setStatusReturn(statusSuccess, "GET "+g.Results[0].Name+": ok")
 }
@@ -277,7 +782,7 @@ func (m model) handleGetNextResult(msg snmp.GetNextMsg) (tea.Model, tea.Cmd) {
 	m.handleSNMPResult(snmp.OpGetNext, "GETNEXT "+msg.OID, msg.Results, msg.Err)
 
 	if msg.Err != nil {
-		return m.setStatusReturn(statusError, "GETNEXT failed: "+msg.Err.Error())
+		return m.setStatusReturn(statusError, errorStatus("GETNEXT failed", msg.Err, m.mib))
 	}
 	return m, nil
 }
@@ -310,20 +815,27 @@ func (m model) handleWalkBatch(msg snmp.WalkBatchMsg) (tea.Model, tea.Cmd) {
 	m.walk = nil
 
 	g := m.results.history.Current()
-	count := 0
+	count, violations := 0, 0
 	if g != nil {
 		count = len(g.Results)
+		violations = countViolations(g)
 	}
 
 	if msg.Err != nil {
+		// Keep what arrived so the walk can be resumed (s r)
+		if g != nil {
+			g.Partial = true
+		}
 		if errors.Is(msg.Err, context.Canceled) {
-			m.setStatus(statusInfo, fmt.Sprintf("Walk cancelled (%d results)", count))
+			m.setStatus(statusInfo, fmt.Sprintf("Walk cancelled (%d results, s r to resume)", count))
 		} else {
 			if g != nil {
 				g.Err = msg.Err
 			}
-			m.setStatus(statusError, "Walk failed: "+msg.Err.Error())
+			m.setStatus(statusError, errorStatus(fmt.Sprintf("Walk stopped after %d results", count), msg.Err, m.mib))
 		}
+	} else if violations > 0 {
+		m.setStatus(statusWarn, fmt.Sprintf("Walk complete: %d results, %d out of spec (v v for report)", count, violations))
 	} else {
 		m.setStatus(statusSuccess, fmt.Sprintf("Walk complete: %d results", count))
 	}
@@ -331,17 +843,240 @@ func (m model) handleWalkBatch(msg snmp.WalkBatchMsg) (tea.Model, tea.Cmd) {
 	return m, clearStatusAfter(statusDisplayDuration)
 }
 
-func (m model) handleTableData(msg snmp.TableDataMsg) (tea.Model, tea.Cmd) {
-	if msg.Err != nil {
+func (m model) handleTableBatch(msg snmp.TableBatchMsg) (tea.Model, tea.Cmd) {
+	if m.tableFetch == nil {
+		// Fetch was cancelled via disconnect, stop processing
+		return m, nil
+	}
+	m.tableData.addCells(msg.Cells, msg.ColumnsDone)
+	if !msg.Done {
+		return m, snmp.WaitTableCmd(m.tableFetch.Ch)
+	}
+
+	name := m.tableFetch.TableName
+	m.tableFetch = nil
+	cancelled := errors.Is(msg.Err, context.Canceled)
+	m.tableData.finishFetch(cancelled)
+	rows := len(m.tableData.allRows)
+
+	switch {
+	case cancelled:
+		return m.setStatusReturn(statusInfo, fmt.Sprintf("TABLE %s: cancelled (%d rows)", name, rows))
+	case msg.Err != nil && rows == 0:
 		m.tableData.setError(msg.Err)
-		return m.setStatusReturn(statusError, "Table fetch failed: "+msg.Err.Error())
+		return m.setStatusReturn(statusError, errorStatus("Table fetch failed", msg.Err, m.mib))
+	case msg.Err != nil:
+		return m.setStatusReturn(statusError, errorStatus(fmt.Sprintf("TABLE %s incomplete (%d rows)", name, rows), msg.Err, m.mib))
 	}
+	return m.setStatusReturn(statusSuccess, fmt.Sprintf("TABLE %s: %d rows", name, rows))
+}
 
-	m.tableData.setData(msg.TableName, msg.Columns, msg.Rows, msg.IndexCols)
-	m.bottomPane = bottomTableData
+func (m model) handleSetResult(msg snmp.SetMsg) (tea.Model, tea.Cmd) {
+	m.setting = false
+	if msg.Err != nil {
+		return m.setStatusReturn(statusError, errorStatus(msg.Label+" failed", msg.Err, m.mib))
+	}
+	m.setStatus(statusSuccess, fmt.Sprintf("%s: ok (%d varbinds)", msg.Label, len(msg.Results)))
+
+	// Re-fetch so the table reflects the agent's view of the row
+	if m.bottomPane == bottomTableData && m.tableDataObj != nil && m.sessionBusy() == "" {
+		m.tableData.setRefreshing()
+		ret, cmd := m.startTableFetch(nil)
+		return ret, tea.Batch(clearStatusAfter(statusDisplayDuration), cmd)
+	}
+	return m, clearStatusAfter(statusDisplayDuration)
+}
+
+// snmpDiscover reads the agent's sysORTable and probes the subtree roots of
+// every loaded module to find out what the agent implements.
+func (m model) snmpDiscover() (tea.Model, tea.Cmd) {
+	if m.watch.active {
+		m.watch.stop()
+	}
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+
+	roots := moduleProbeRoots(m.mib.Root())
+	oids, _ := probeRootIndex(roots)
+	m.discovering = true
+	m.setStatus(statusInfo, fmt.Sprintf("DISCOVER: probing %d subtrees...", len(oids)))
+	probe := snmp.DiscoverCmd(m.snmp, oids)
+	return m, func() tea.Msg {
+		return discoverDoneMsg{roots: roots, DiscoverMsg: probe().(snmp.DiscoverMsg)}
+	}
+}
+
+func (m model) handleDiscoverResult(done discoverDoneMsg) (tea.Model, tea.Cmd) {
+	m.discovering = false
+	if m.snmp == nil {
+		return m, nil // disconnected while probing
+	}
+
+	msg, roots := done.DiscoverMsg, done.roots
+	_, moduleOf := probeRootIndex(roots)
+	m.support.set(m.snmp.Target, msg, moduleOf)
+	if m.tree.filterActive {
+		m.tree.rebuild()
+	}
+
+	totals := make(map[string]int)
+	firstRoot := make(map[string]string)
+	for _, r := range roots {
+		totals[r.module]++
+		if _, ok := firstRoot[r.module]; !ok {
+			firstRoot[r.module] = r.node.OID().String()
+		}
+	}
+
+	g := snmp.ResultGroup{Op: snmp.OpDiscover, Label: "DISCOVER " + m.snmp.Target, Err: msg.Err}
+	for _, e := range msg.SysOR {
+		name := e.ID
+		if oid, err := mib.ParseOID(e.ID); err == nil {
+			if node := m.mib.NodeByOID(oid); node != nil {
+				name = node.Name()
+			}
+		}
+		g.Results = append(g.Results, discoverResult(e.ID, name, e.Descr, "sysOR"))
+	}
+	for _, mod := range m.support.moduleNames() {
+		value := fmt.Sprintf("%d of %d subtrees", m.support.modules[mod], totals[mod])
+		g.Results = append(g.Results, discoverResult(firstRoot[mod], mod, value, "MODULE"))
+	}
+	m.results.addGroup(g)
+	m.bottomPane = bottomResults
+	m.focus = focusResults
 	m.updateLayout()
 
-	return m.setStatusReturn(statusSuccess, fmt.Sprintf("TABLE %s: %d rows", msg.TableName, len(msg.Rows)))
+	if msg.Err != nil {
+		return m.setStatusReturn(statusError, errorStatus(fmt.Sprintf("DISCOVER stopped after %d subtrees", msg.Probed), msg.Err, m.mib))
+	}
+	return m.setStatusReturn(statusSuccess, fmt.Sprintf("DISCOVER: %d modules answered, %d sysORTable entries",
+		len(m.support.modules), len(msg.SysOR)))
+}
+
+// discoverResult builds a synthetic result row for the discovery summary.
+func discoverResult(oid, name, value, typeName string) snmp.Result {
+	return snmp.Result{
+		OID:      oid,
+		Name:     name,
+		Value:    value,
+		TypeName: typeName,
+		PDU:      gosnmp.SnmpPDU{Name: oid, Type: gosnmp.OctetString, Value: []byte(value)},
+	}
+}
+
+// snmpComplianceCheck walks the objects of the selected MODULE-COMPLIANCE
+// and reports which of its groups the agent implements.
+func (m model) snmpComplianceCheck() (tea.Model, tea.Cmd) {
+	if m.watch.active {
+		m.watch.stop()
+	}
+	if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+		return ret, retCmd
+	}
+
+	node := m.tree.selectedNode()
+	if node == nil || node.Compliance() == nil {
+		return m.setStatusReturn(statusWarn, "Select a MODULE-COMPLIANCE node")
+	}
+
+	check := newComplianceCheck(node.Compliance(), m.mib)
+	oids := check.oids()
+	if len(oids) == 0 {
+		return m.setStatusReturn(statusWarn, "No loaded groups in "+node.Name())
+	}
+	m.compliance = check
+	m.setStatus(statusInfo, fmt.Sprintf("CHECK %s: walking %d objects...", node.Name(), len(oids)))
+	return m, snmp.CollectCmd(m.snmp, check.label, oids, complianceValueLimit)
+}
+
+func (m model) handleCollectResult(msg snmp.CollectMsg) (tea.Model, tea.Cmd) {
+	if m.summary != nil && m.summary.label == msg.Label {
+		next := m.followNeighbors()
+		ret, cmd := m.handleSummaryResult(msg)
+		return ret, tea.Batch(cmd, next)
+	}
+	if m.ifaces.active && m.ifaces.pollLabel() == msg.Label {
+		return m.handleInterfacePoll(msg)
+	}
+	if m.inventory.loading && m.inventory.label == msg.Label {
+		return m.handleInventoryResult(msg)
+	}
+	if m.neighbors.loading && m.neighbors.label == msg.Label {
+		return m.handleNeighborResult(msg)
+	}
+	check := m.compliance
+	if check == nil || check.label != msg.Label || m.snmp == nil {
+		return m, nil // superseded or disconnected
+	}
+	m.compliance = nil
+
+	target := m.snmp.Target
+	m.report.set("Compliance: "+check.comp.Name(), complianceReportFile(check.comp, target),
+		check.report(target, msg.PDUs, msg.Err, m.mib))
+	m.topPane = topReport
+	m.focus = focusDetail
+
+	if msg.Err != nil {
+		return m.setStatusReturn(statusError, errorStatus("CHECK "+check.comp.Name()+" incomplete", msg.Err, m.mib))
+	}
+	return m.setStatusReturn(statusSuccess, "CHECK "+check.comp.Name()+": done (w to save)")
+}
+
+// draftCapabilities builds an AGENT-CAPABILITIES module from the results
+// group currently shown and opens it in the report pane.
+func (m model) draftCapabilities() (tea.Model, tea.Cmd) {
+	if m.walk != nil {
+		return m.setStatusReturn(statusWarn, "Walk in progress")
+	}
+	g := m.results.history.Current()
+	if g == nil || g.Op != snmp.OpWalk || g.Partial || g.Err != nil || len(g.Results) == 0 {
+		return m.setStatusReturn(statusWarn, "Draft capabilities from the results of a complete walk")
+	}
+
+	target := m.lastDevice.Profile.Target
+	if target == "" {
+		target = "agent"
+	}
+	draft, err := buildCapabilityDraft(g, m.mib, target)
+	if err != nil {
+		return m.setStatusReturn(statusWarn, "Cannot draft capabilities: "+err.Error())
+	}
+	m.report.set("AGENT-CAPABILITIES: "+draft.moduleName(), draft.fileName(), draft.render())
+	m.topPane = topReport
+	m.focus = focusDetail
+	return m.setStatusReturn(statusSuccess, fmt.Sprintf("Drafted capabilities for %d modules (w to save)", len(draft.supports)))
+}
+
+// conformanceReport opens a report of the out-of-spec values in the
+// results group currently shown.
+func (m model) conformanceReport() (tea.Model, tea.Cmd) {
+	g := m.results.history.Current()
+	if g == nil || len(g.Results) == 0 {
+		return m.setStatusReturn(statusWarn, "No results to check")
+	}
+	target := m.lastDevice.Profile.Target
+	if target == "" {
+		target = "agent"
+	}
+	m.report.set("Conformance: "+g.Label, conformanceReportFile(target), buildConformanceReport(g, m.mib, target))
+	m.topPane = topReport
+	m.focus = focusDetail
+	return m, nil
+}
+
+// wireInspector opens, or refreshes, the packet inspector on the session's
+// most recent exchanges. It works mid-walk so a stalled walk can be seen.
+func (m model) wireInspector() (tea.Model, tea.Cmd) {
+	if !m.snmp.IsConnected() {
+		return m.setStatusReturn(statusError, "Not connected")
+	}
+	file := "wire-" + strings.NewReplacer(":", "_", "/", "_").Replace(m.snmp.Target) + ".txt"
+	m.report.set(wireReportTitle, file, buildWireReport(m.snmp, m.mib))
+	m.topPane = topReport
+	m.focus = focusDetail
+	return m, nil
 }
 
 func (m model) saveProfile() (tea.Model, tea.Cmd) {
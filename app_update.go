@@ -54,6 +54,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.overlay.kind == overlayConnect && m.dialog != nil {
 			return m.handleDialogClick(msg)
 		}
+		if m.overlay.kind == overlayRowEdit && m.rowEditor != nil {
+			return m.handleRowEditorClick(msg)
+		}
 		if !m.overlay.isDialog() {
 			m.tooltip.hide()
 			return m.handleMouseClick(msg)
@@ -85,23 +88,67 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case snmp.ConnectMsg:
 		if msg.Err != nil {
+			m.neighbors.pending = ""
 			return m.setStatusReturn(statusError, "Connect: "+msg.Err.Error())
 		}
+		if m.ifaces.active {
+			m.ifaces.stop()
+		}
+		if m.neighbors.arrive(m.lastDevice) && m.bottomPane == bottomNeighbors {
+			// Read the new device's neighbors once it has been identified.
+			m.neighbors.start(msg.Session.Target, cdpLoaded(m.mib))
+			m.neighbors.follow = true
+		}
 		m.snmp = msg.Session
+		m.identity = deviceIdentity{}
+		m.support.reset()
+		if m.tree.filterActive {
+			m.tree.rebuild()
+		}
 		m.overlay.kind = overlayNone
 		m.dialog = nil
-		return m.setStatusReturn(statusSuccess, "Connected to "+msg.Session.Target)
+		m.identifying = true
+		m.setStatus(statusSuccess, "Connected to "+msg.Session.Target)
+		return m, tea.Batch(clearStatusAfter(statusDisplayDuration), snmp.IdentifyCmd(m.snmp))
+
+	case snmp.IdentifyMsg:
+		return m.handleIdentify(msg)
+
+	case mibReloadMsg:
+		return m.handleMibReload(msg)
+
+	case mibWatchTickMsg:
+		return m.handleMibWatchTick(msg)
+
+	case mibEditMsg:
+		return m.handleMibEdit(msg)
 
 	case snmp.DisconnectMsg:
 		if m.watch.active {
 			m.watch.stop()
 		}
+		if m.ifaces.active {
+			m.ifaces.stop()
+		}
 		if m.walk != nil {
 			m.walk.Cancel()
 			m.walk = nil
 			m.results.walkStatus = ""
 		}
+		if m.tableFetch != nil {
+			m.tableFetch.Cancel()
+			m.tableFetch = nil
+			m.tableData.finishFetch(true)
+		}
 		m.snmp = nil
+		m.discovering = false
+		m.identifying = false
+		m.setting = false
+		m.identity = deviceIdentity{}
+		m.summary = nil
+		m.compliance = nil
+		m.inventory.loading = false
+		m.neighbors.loading = false
 		return m.setStatusReturn(statusInfo, "Disconnected")
 
 	case snmp.GetMsg:
@@ -110,11 +157,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case snmp.GetNextMsg:
 		return m.handleGetNextResult(msg)
 
+	case snmp.ScanBatchMsg:
+		return m.handleScanBatch(msg)
+
 	case snmp.WalkBatchMsg:
 		return m.handleWalkBatch(msg)
 
-	case snmp.TableDataMsg:
-		return m.handleTableData(msg)
+	case snmp.TableBatchMsg:
+		return m.handleTableBatch(msg)
+
+	case snmp.SetMsg:
+		return m.handleSetResult(msg)
+
+	case discoverDoneMsg:
+		return m.handleDiscoverResult(msg)
+
+	case snmp.CollectMsg:
+		return m.handleCollectResult(msg)
 
 	case snmp.WatchTickMsg:
 		if !m.watch.active || msg.Seq != m.watch.pollSeq {
@@ -132,19 +191,50 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.Err != nil {
 			m.watch.polling = false
-			m.setStatus(statusError, "Watch poll failed: "+msg.Err.Error())
+			m.setStatus(statusError, errorStatus("Watch poll failed", msg.Err, m.mib))
 			return m, tea.Batch(clearStatusAfter(statusDisplayDuration), m.watch.scheduleNextTick())
 		}
 		m.watch.handlePoll(msg.PDUs, m.mib, m.watch.interval)
 		m.updateLayout()
 		return m, m.watch.scheduleNextTick()
 
+	case ifaceTickMsg:
+		if !m.ifaces.active || msg.seq != m.ifaces.pollSeq {
+			return m, nil // stale tick
+		}
+		if m.bottomPane != bottomInterfaces {
+			m.ifaces.stop() // pause while another pane has the bottom
+			return m, nil
+		}
+		if m.ifaces.polling || m.sessionBusy() != "" {
+			return m, m.ifaces.scheduleNextTick() // wait for the session
+		}
+		return m, m.ifaces.startPollCmd(m.snmp)
+
 	case deviceDialogSubmitMsg:
 		m.overlay.kind = overlayNone
 		m.dialog = nil
+		if m.neighbors.pending != "" && m.neighbors.pending == msg.device.Target {
+			return m.switchDevice(msg.device) // hop to a neighbor
+		}
 		m.lastDevice = msg.device
 		return m, snmp.ConnectCmd(msg.device.Profile)
 
+	case scanDialogSubmitMsg:
+		m.overlay.kind = overlayNone
+		m.dialog = nil
+		return m.startScan(msg.prefix, msg.profile)
+
+	case rowEditorSubmitMsg:
+		m.overlay.kind = overlayNone
+		m.rowEditor = nil
+		if ret, retCmd, ok := m.requireConnectedIdle(); !ok {
+			return ret, retCmd
+		}
+		m.setting = true
+		m.setStatus(statusInfo, msg.label+"...")
+		return m, snmp.SetCmd(m.snmp, msg.label, msg.pdus)
+
 	case snapshotMsg:
 		if msg.err != nil {
 			return m.setStatusReturn(statusError, "Snapshot failed: "+msg.err.Error())
@@ -200,6 +290,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// Row editor swallows all keys
+		if m.overlay.kind == overlayRowEdit && m.rowEditor != nil {
+			cmd, closed := m.rowEditor.update(msg)
+			if closed {
+				m.overlay.kind = overlayNone
+				m.rowEditor = nil
+			}
+			return m, cmd
+		}
+
 		// Pending chord: resolve or cancel
 		if m.pendingChord != "" {
 			prefix := m.pendingChord
@@ -213,7 +313,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Chord prefix activation (only in tree/results/detail/watch focus)
 		if m.focus == focusTree || m.focus == focusResults || m.focus == focusDetail || m.focus == focusWatch {
 			switch msg.String() {
-			case "s", "c", "v":
+			case "s", "c", "v", "m":
 				m.pendingChord = msg.String()
 				return m, nil
 			}
@@ -250,10 +350,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateResults(msg)
 		case focusResultFilter:
 			return m.updateResultFilter(msg)
+		case focusTableFilter:
+			return m.updateTableFilter(msg)
 		case focusXref:
 			return m.updateXref(msg)
 		case focusColumnPicker:
 			return m.updateColumnPicker(msg)
+		case focusFilePicker:
+			return m.updateFilePicker(msg)
 		}
 	}
 	return m, nil
@@ -354,6 +458,22 @@ func (m model) openContextMenu(x, y int) (tea.Model, tea.Cmd) {
 			row := y - l.rightBot.Min.Y - watchHeaderLines + m.watch.lv.Offset()
 			m.watch.clickRow(row)
 			items = watchMenuItems(m)
+		case bottomScan:
+			row := y - l.rightBot.Min.Y - scanHeaderLines + m.scanList.lv.Offset()
+			m.scanList.clickRow(row)
+			items = scanMenuItems(m)
+		case bottomInterfaces:
+			row := y - l.rightBot.Min.Y - ifaceHeaderLines + m.ifaces.lv.Offset()
+			m.ifaces.clickRow(row)
+			items = ifaceMenuItems(m)
+		case bottomInventory:
+			row := y - l.rightBot.Min.Y - inventoryHeaderLines + m.inventory.lv.Offset()
+			m.inventory.clickRow(row)
+			items = inventoryMenuItems(m)
+		case bottomNeighbors:
+			row := y - l.rightBot.Min.Y - neighborHeaderLines + m.neighbors.lv.Offset()
+			m.neighbors.clickRow(row)
+			items = neighborMenuItems(m)
 		}
 	} else if pt.In(l.rightTop) {
 		items = detailMenuItems(m)
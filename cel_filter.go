@@ -18,36 +18,60 @@ type celFilter struct {
 	envErr     string         // CEL environment init error, "" if ok
 	matchCount int            // direct matches from last evaluation
 	activation map[string]any // reusable activation map for eval calls
+	support    *agentSupport  // discovery results for "implemented", may be nil
+}
+
+// celNodeVar declares a CEL variable describing a MIB node.
+type celNodeVar struct {
+	name string
+	typ  *cel.Type
+}
+
+// celNodeVars are the node metadata variables exposed by the tree filter.
+// The result filter declares the same set so queries carry over.
+var celNodeVars = []celNodeVar{
+	{"name", cel.StringType},
+	{"oid", cel.StringType},
+	{"kind", cel.StringType},
+	{"module", cel.StringType},
+	{"status", cel.StringType},
+	{"access", cel.StringType},
+	{"type_name", cel.StringType},
+	{"base_type", cel.StringType},
+	{"description", cel.StringType},
+	{"units", cel.StringType},
+	{"display_hint", cel.StringType},
+	{"language", cel.StringType},
+	{"is_tc", cel.BoolType},
+	{"is_table", cel.BoolType},
+	{"is_row", cel.BoolType},
+	{"is_column", cel.BoolType},
+	{"is_scalar", cel.BoolType},
+	{"is_counter", cel.BoolType},
+	{"is_gauge", cel.BoolType},
+	{"is_string", cel.BoolType},
+	{"is_enum", cel.BoolType},
+	{"is_bits", cel.BoolType},
+	{"arc", cel.UintType},
+	{"depth", cel.IntType},
+	{"implemented", cel.BoolType},
+}
+
+// celNodeEnvOptions returns variable declarations for celNodeVars plus any
+// extra variables, with the string extension library enabled.
+func celNodeEnvOptions(extra ...celNodeVar) []cel.EnvOption {
+	opts := make([]cel.EnvOption, 0, len(celNodeVars)+len(extra)+1)
+	for _, v := range celNodeVars {
+		opts = append(opts, cel.Variable(v.name, v.typ))
+	}
+	for _, v := range extra {
+		opts = append(opts, cel.Variable(v.name, v.typ))
+	}
+	return append(opts, ext.Strings())
 }
 
 func newCelFilter() *celFilter {
-	env, err := cel.NewEnv(
-		cel.Variable("name", cel.StringType),
-		cel.Variable("oid", cel.StringType),
-		cel.Variable("kind", cel.StringType),
-		cel.Variable("module", cel.StringType),
-		cel.Variable("status", cel.StringType),
-		cel.Variable("access", cel.StringType),
-		cel.Variable("type_name", cel.StringType),
-		cel.Variable("base_type", cel.StringType),
-		cel.Variable("description", cel.StringType),
-		cel.Variable("units", cel.StringType),
-		cel.Variable("display_hint", cel.StringType),
-		cel.Variable("language", cel.StringType),
-		cel.Variable("is_tc", cel.BoolType),
-		cel.Variable("is_table", cel.BoolType),
-		cel.Variable("is_row", cel.BoolType),
-		cel.Variable("is_column", cel.BoolType),
-		cel.Variable("is_scalar", cel.BoolType),
-		cel.Variable("is_counter", cel.BoolType),
-		cel.Variable("is_gauge", cel.BoolType),
-		cel.Variable("is_string", cel.BoolType),
-		cel.Variable("is_enum", cel.BoolType),
-		cel.Variable("is_bits", cel.BoolType),
-		cel.Variable("arc", cel.UintType),
-		cel.Variable("depth", cel.IntType),
-		ext.Strings(),
-	)
+	env, err := cel.NewEnv(celNodeEnvOptions()...)
 	if err != nil {
 		return &celFilter{envErr: fmt.Sprintf("cel env init: %v", err)}
 	}
@@ -70,39 +94,56 @@ func (f *celFilter) compile(expr string) {
 		return
 	}
 
-	ast, issues := f.env.Compile(expr)
+	f.program, f.err = compileBoolProgram(f.env, expr)
+}
+
+// compileBoolProgram compiles a CEL expression that must evaluate to bool.
+// On failure it returns a nil program and the error text.
+func compileBoolProgram(env *cel.Env, expr string) (cel.Program, string) {
+	ast, issues := env.Compile(expr)
 	if issues != nil && issues.Err() != nil {
-		f.err = issues.Err().Error()
-		return
+		return nil, issues.Err().Error()
 	}
 
 	// Verify output type is bool
 	if ast.OutputType() != cel.BoolType {
-		f.err = "expression must return bool"
-		return
+		return nil, "expression must return bool"
 	}
 
-	prg, err := f.env.Program(ast)
+	prg, err := env.Program(ast)
 	if err != nil {
-		f.err = err.Error()
-		return
+		return nil, err.Error()
 	}
-	f.program = prg
+	return prg, ""
 }
 
 func (f *celFilter) buildActivation(node *mib.Node) map[string]any {
 	vars := f.activation
 	if vars == nil {
-		vars = make(map[string]any, 25)
+		vars = make(map[string]any, len(celNodeVars))
 		f.activation = vars
 	}
+	setNodeVars(vars, node, f.support)
+	return vars
+}
 
+// setNodeVars fills vars with the celNodeVars values for a node. A nil node
+// yields empty defaults. implemented is false unless support has discovery
+// results covering the node.
+func setNodeVars(vars map[string]any, node *mib.Node, support *agentSupport) {
 	// Always-present fields
-	vars["name"] = node.Name()
-	vars["oid"] = node.OID().String()
-	vars["kind"] = node.Kind().String()
-	vars["arc"] = uint64(node.Arc())
-	vars["depth"] = int64(len(node.OID()))
+	vars["name"] = ""
+	vars["oid"] = ""
+	vars["kind"] = ""
+	vars["arc"] = uint64(0)
+	vars["depth"] = int64(0)
+	if node != nil {
+		vars["name"] = node.Name()
+		vars["oid"] = node.OID().String()
+		vars["kind"] = node.Kind().String()
+		vars["arc"] = uint64(node.Arc())
+		vars["depth"] = int64(len(node.OID()))
+	}
 
 	// Reset optional fields to defaults
 	vars["module"] = ""
@@ -124,6 +165,13 @@ func (f *celFilter) buildActivation(node *mib.Node) map[string]any {
 	vars["is_string"] = false
 	vars["is_enum"] = false
 	vars["is_bits"] = false
+	vars["implemented"] = false
+
+	if node == nil {
+		return
+	}
+
+	vars["implemented"] = support.implements(node)
 
 	if mod := node.Module(); mod != nil {
 		vars["module"] = mod.Name()
@@ -154,8 +202,6 @@ func (f *celFilter) buildActivation(node *mib.Node) map[string]any {
 		vars["status"] = status.String()
 		vars["description"] = desc
 	}
-
-	return vars
 }
 
 func (f *celFilter) eval(node *mib.Node) bool {
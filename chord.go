@@ -27,9 +27,17 @@ func chordGroups() []chordGroup {
 				{key: "g", label: "GET"},
 				{key: "n", label: "GETNEXT"},
 				{key: "w", label: "WALK"},
+				{key: "r", label: "resume WALK"},
 				{key: "t", label: "TABLE"},
 				{key: "p", label: "POLL (watch)"},
 				{key: "q", label: "query by OID"},
+				{key: "d", label: "discover implemented"},
+				{key: "c", label: "compliance check"},
+				{key: "a", label: "draft AGENT-CAPABILITIES"},
+				{key: "i", label: "device summary"},
+				{key: "f", label: "interface dashboard"},
+				{key: "e", label: "physical inventory"},
+				{key: "l", label: "neighbors"},
 			},
 		},
 		{
@@ -39,6 +47,7 @@ func chordGroups() []chordGroup {
 				{key: "c", label: "connect"},
 				{key: "d", label: "disconnect"},
 				{key: "s", label: "save profile"},
+				{key: "n", label: "scan subnet"},
 			},
 		},
 		{
@@ -54,10 +63,28 @@ func chordGroups() []chordGroup {
 				{key: "t", label: "tree/flat (results)"},
 				{key: "o", label: "raw OIDs (results)"},
 				{key: "c", label: "columns (table)"},
+				{key: "p", label: "last report"},
+				{key: "v", label: "conformance report (results)"},
+				{key: "w", label: "packet inspector"},
+				{key: "n", label: "scan results"},
+				{key: "f", label: "interfaces"},
+				{key: "e", label: "inventory"},
+				{key: "l", label: "neighbors"},
 				{key: ",", label: "shrink tree"},
 				{key: ".", label: "grow tree"},
 			},
 		},
+		{
+			prefix: "m",
+			label:  "MIBs",
+			actions: []chordAction{
+				{key: "r", label: "reload"},
+				{key: "w", label: "watch MIB paths"},
+				{key: "l", label: "load file/dir/archive"},
+				{key: "s", label: "view source"},
+				{key: "e", label: "edit in $EDITOR"},
+			},
+		},
 	}
 }
 
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+// complianceValueLimit caps the instances fetched per object when checking
+// values, so a large table doesn't stall the check.
+const complianceValueLimit = 500
+
+// complianceShownViolations is the number of out-of-spec values listed per
+// object before the rest are summarized.
+const complianceShownViolations = 5
+
+// complianceGroup is a GROUP or MANDATORY-GROUPS reference to check.
+type complianceGroup struct {
+	module    string
+	name      string
+	group     *mib.Group // nil when the group is not loaded
+	mandatory bool
+	refine    map[string]mib.ComplianceObject // OBJECT refinements by object name
+}
+
+// complianceCheck is a MODULE-COMPLIANCE resolved against the loaded MIBs,
+// ready to be compared with what an agent returns.
+type complianceCheck struct {
+	comp   *mib.Compliance
+	label  string // CollectCmd label, matched against the response
+	groups []complianceGroup
+}
+
+func newComplianceCheck(comp *mib.Compliance, m *mib.Mib) *complianceCheck {
+	c := &complianceCheck{comp: comp, label: "compliance " + comp.Name()}
+	for _, cm := range comp.Modules() {
+		modName := cm.ModuleName
+		if modName == "" && comp.Module() != nil {
+			modName = comp.Module().Name()
+		}
+		mod := m.Module(modName)
+		refine := make(map[string]mib.ComplianceObject, len(cm.Objects))
+		for _, co := range cm.Objects {
+			refine[co.Object] = co
+		}
+		lookup := func(name string, mandatory bool) {
+			g := complianceGroup{module: modName, name: name, mandatory: mandatory, refine: refine}
+			if mod != nil {
+				g.group = mod.Group(name)
+			}
+			c.groups = append(c.groups, g)
+		}
+		for _, name := range cm.MandatoryGroups {
+			lookup(name, true)
+		}
+		for _, cg := range cm.Groups {
+			lookup(cg.Group, false)
+		}
+	}
+	return c
+}
+
+// oids returns the distinct object OIDs to walk.
+func (c *complianceCheck) oids() []string {
+	seen := make(map[string]bool)
+	var oids []string
+	for _, g := range c.groups {
+		for _, obj := range groupObjects(g.group) {
+			oid := obj.OID().String()
+			if !seen[oid] {
+				seen[oid] = true
+				oids = append(oids, oid)
+			}
+		}
+	}
+	return oids
+}
+
+// groupObjects returns the object members of a group, skipping
+// notifications. A nil group has none.
+func groupObjects(grp *mib.Group) []*mib.Object {
+	if grp == nil {
+		return nil
+	}
+	var objs []*mib.Object
+	for _, member := range grp.Members() {
+		if obj := member.Object(); obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// accessRank orders access levels so MIN-ACCESS can be compared with the
+// declared MAX-ACCESS.
+func accessRank(a mib.Access) int {
+	switch a {
+	case mib.AccessAccessibleForNotify:
+		return 1
+	case mib.AccessReadOnly:
+		return 2
+	case mib.AccessReadWrite, mib.AccessWriteOnly:
+		return 3
+	case mib.AccessReadCreate:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// isReadable reports whether an agent is expected to return instances of
+// an object with the given access.
+func isReadable(a mib.Access) bool {
+	return a == mib.AccessReadOnly || a == mib.AccessReadWrite || a == mib.AccessReadCreate
+}
+
+// report compares the collected instances with the compliance and renders
+// the result.
+func (c *complianceCheck) report(target string, pdus map[string][]gosnmp.SnmpPDU, err error, m *mib.Mib) []reportLine {
+	var b reportBuilder
+	var body reportBuilder
+	var mismatches, violations reportBuilder
+
+	var full, partial, none, mandatoryMissing, missingObjs, mismatchCount, violationCount int
+	checked := make(map[*mib.Object]bool)
+
+	lastModule := ""
+	for _, g := range c.groups {
+		if g.module != lastModule {
+			if lastModule != "" {
+				body.blank()
+			}
+			body.add(reportHeading, "MODULE %s", g.module)
+			lastModule = g.module
+		}
+		kind := "conditional"
+		if g.mandatory {
+			kind = "mandatory"
+		}
+		if g.group == nil {
+			body.add(reportWarn, "  %-8s %-36s %s", "UNKNOWN", g.name, kind+", group not loaded")
+			continue
+		}
+		if g.group.IsNotificationGroup() {
+			body.add(reportMuted, "  %-8s %-36s %s", "SKIPPED", g.name, kind+", notification group")
+			continue
+		}
+
+		var required, present int
+		var missing, optional []string
+		for _, obj := range groupObjects(g.group) {
+			got := pdus[obj.OID().String()]
+			co, refined := g.refine[obj.Name()]
+
+			if !checked[obj] {
+				checked[obj] = true
+				if !isReadable(obj.Access()) && len(got) > 0 {
+					mismatchCount++
+					mismatches.add(reportWarn, "  %s: declared %s, agent returned %d instances",
+						obj.Name(), obj.Access(), len(got))
+				}
+				if refined && co.MinAccess != nil && accessRank(*co.MinAccess) > accessRank(obj.Access()) {
+					mismatchCount++
+					mismatches.add(reportWarn, "  %s: MIN-ACCESS %s exceeds declared %s",
+						obj.Name(), co.MinAccess, obj.Access())
+				}
+				constraints := snmp.ObjectConstraints(obj)
+				if refined {
+					constraints = constraints.Refine(co.Syntax)
+				}
+				shown := 0
+				for _, pdu := range got {
+					problem := snmp.CheckValue(pdu, constraints)
+					if problem == "" {
+						continue
+					}
+					violationCount++
+					if shown < complianceShownViolations {
+						name := snmp.FormatPDUToResult(pdu, m).Name
+						violations.add(reportBad, "  %s: %s", name, problem)
+					}
+					shown++
+				}
+				if shown > complianceShownViolations {
+					violations.add(reportMuted, "  %s: %d more", obj.Name(), shown-complianceShownViolations)
+				}
+			}
+
+			if !isReadable(obj.Access()) {
+				continue
+			}
+			if refined && co.MinAccess != nil && !isReadable(*co.MinAccess) {
+				if len(got) == 0 {
+					optional = append(optional, obj.Name())
+				}
+				continue
+			}
+			required++
+			if len(got) > 0 {
+				present++
+			} else {
+				missing = append(missing, obj.Name())
+			}
+		}
+
+		status, kindStyle := "FULL", reportGood
+		switch {
+		case required > 0 && present == 0:
+			status, kindStyle = "NONE", reportBad
+			none++
+			if g.mandatory {
+				mandatoryMissing++
+			}
+		case present < required:
+			status, kindStyle = "PARTIAL", reportWarn
+			partial++
+			if g.mandatory {
+				mandatoryMissing++
+			}
+		default:
+			full++
+		}
+		if !g.mandatory && status == "NONE" {
+			kindStyle = reportMuted
+		}
+		body.add(kindStyle, "  %-8s %-36s %s, %d/%d objects", status, g.name, kind, present, required)
+		missingObjs += len(missing)
+		if len(missing) > 0 {
+			body.add(reportPlain, "             missing: %s", strings.Join(missing, ", "))
+		}
+		if len(optional) > 0 {
+			body.add(reportMuted, "             absent (MIN-ACCESS not-accessible): %s", strings.Join(optional, ", "))
+		}
+	}
+
+	mod := ""
+	if c.comp.Module() != nil {
+		mod = c.comp.Module().Name() + "::"
+	}
+	b.add(reportPlain, "Agent       %s", target)
+	b.add(reportPlain, "Compliance  %s%s", mod, c.comp.Name())
+	b.blank()
+	if err != nil {
+		b.add(reportBad, "Walk stopped early: %s", snmp.ErrorText(err, m))
+		b.add(reportBad, "Objects after the failure are reported as missing.")
+		b.blank()
+	}
+	summaryKind := reportGood
+	if mandatoryMissing > 0 || violationCount > 0 {
+		summaryKind = reportBad
+	} else if partial > 0 || mismatchCount > 0 {
+		summaryKind = reportWarn
+	}
+	b.add(summaryKind, "%d groups fully, %d partially, %d not implemented (%d mandatory incomplete)",
+		full, partial, none, mandatoryMissing)
+	b.add(summaryKind, "%d missing objects, %d access mismatches, %d out-of-spec values",
+		missingObjs, mismatchCount, violationCount)
+	b.blank()
+	b.lines = append(b.lines, body.lines...)
+
+	if len(mismatches.lines) > 0 {
+		b.blank()
+		b.add(reportHeading, "Access mismatches")
+		b.lines = append(b.lines, mismatches.lines...)
+	}
+	if len(violations.lines) > 0 {
+		b.blank()
+		b.add(reportHeading, "Values outside the refined syntax")
+		b.lines = append(b.lines, violations.lines...)
+	}
+	b.blank()
+	b.add(reportMuted, "Write access is not probed; access checks cover readability only.")
+	return b.lines
+}
+
+// complianceReportFile is the default file name for a saved report.
+func complianceReportFile(comp *mib.Compliance, target string) string {
+	return fmt.Sprintf("%s-%s.txt", comp.Name(), strings.NewReplacer(":", "_", "/", "_").Replace(target))
+}
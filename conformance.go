@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+)
+
+// conformanceShownInstances is the number of offending instances listed
+// per object in a conformance report.
+const conformanceShownInstances = 10
+
+// conformanceObject gathers the out-of-spec instances of one object.
+type conformanceObject struct {
+	name      string
+	module    string
+	syntax    string
+	instances []snmp.Result
+}
+
+// countViolations returns the number of out-of-spec results in a group.
+func countViolations(g *snmp.ResultGroup) int {
+	n := 0
+	for i := range g.Results {
+		if g.Results[i].Violation != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// buildConformanceReport lists every out-of-spec value in a result group,
+// grouped by object, with a per-module summary.
+func buildConformanceReport(g *snmp.ResultGroup, m *mib.Mib, target string) []reportLine {
+	byObject := make(map[string]*conformanceObject)
+	var order []string
+	checked := 0
+	for _, res := range g.Results {
+		if !snmp.HasValue(res.PDU) {
+			continue
+		}
+		checked++
+		if res.Violation == "" {
+			continue
+		}
+		key, co := res.Name, &conformanceObject{name: res.Name}
+		if oid, err := mib.ParseOID(res.OID); err == nil {
+			if node := m.LongestPrefixByOID(oid); node != nil {
+				key = node.OID().String()
+				co.name = node.Name()
+				if mod := node.Module(); mod != nil {
+					co.module = mod.Name()
+				}
+				if obj := node.Object(); obj != nil && obj.Type() != nil {
+					co.syntax = typeLabel(obj.Type())
+				}
+			}
+		}
+		if existing, ok := byObject[key]; ok {
+			co = existing
+		} else {
+			byObject[key] = co
+			order = append(order, key)
+		}
+		co.instances = append(co.instances, res)
+	}
+
+	var b reportBuilder
+	b.add(reportPlain, "Agent   %s", target)
+	b.add(reportPlain, "Source  %s", g.Label)
+	b.blank()
+
+	total := 0
+	perModule := make(map[string]int)
+	for _, key := range order {
+		co := byObject[key]
+		total += len(co.instances)
+		perModule[co.module]++
+	}
+	if total == 0 {
+		b.add(reportGood, "All %d values conform to their declared syntax.", checked)
+		return b.lines
+	}
+	b.add(reportBad, "%d of %d values out of spec across %d objects", total, checked, len(order))
+
+	modules := make([]string, 0, len(perModule))
+	for mod := range perModule {
+		modules = append(modules, mod)
+	}
+	slices.Sort(modules)
+	for _, mod := range modules {
+		name := mod
+		if name == "" {
+			name = "(unknown module)"
+		}
+		b.add(reportMuted, "  %-32s %d objects", name, perModule[mod])
+	}
+
+	for _, key := range order {
+		co := byObject[key]
+		b.blank()
+		heading := co.name
+		if co.module != "" {
+			heading = co.module + "::" + co.name
+		}
+		if co.syntax != "" {
+			heading += " (" + co.syntax + ")"
+		}
+		b.add(reportHeading, "%s: %d instances", heading, len(co.instances))
+		for i, res := range co.instances {
+			if i == conformanceShownInstances {
+				b.add(reportMuted, "  ... %d more", len(co.instances)-i)
+				break
+			}
+			b.add(reportWarn, "  %s = %s", res.Name, res.Value)
+			b.add(reportPlain, "      %s", res.Violation)
+		}
+	}
+	return b.lines
+}
+
+// conformanceReportFile is the default file name for a saved report.
+func conformanceReportFile(target string) string {
+	return fmt.Sprintf("conformance-%s.txt", strings.ToLower(capabilityIdent(target)))
+}
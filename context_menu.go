@@ -218,6 +218,8 @@ func treeMenuItems(m model) []contextMenuItem {
 		}
 	}
 
+	isCompliance := node != nil && node.Compliance() != nil
+
 	hasKids := false
 	isExpanded := false
 	if node != nil {
@@ -243,6 +245,9 @@ func treeMenuItems(m model) []contextMenuItem {
 		{label: "WATCH", key: "sp", enabled: snmpReady, action: func(m model) (tea.Model, tea.Cmd) {
 			return m.snmpWatch()
 		}},
+		{label: "Check Compliance", key: "sc", enabled: connected && idle && isCompliance, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.snmpComplianceCheck()
+		}},
 		contextSep(),
 		{label: "Copy OID", key: "y", enabled: hasOID, action: func(m model) (tea.Model, tea.Cmd) {
 			if n := m.tree.selectedNode(); n != nil {
@@ -251,6 +256,15 @@ func treeMenuItems(m model) []contextMenuItem {
 			return m, nil
 		}},
 		xrefMenuItem(node, m.xrefs, func(m model) *mib.Node { return m.tree.selectedNode() }),
+		{label: "View Source", key: "ms", enabled: node != nil && node.Module() != nil, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.viewNodeSource()
+		}},
+		{label: "Edit Source", key: "me", enabled: node != nil && node.Module() != nil, action: func(m model) (tea.Model, tea.Cmd) {
+			if n := m.tree.selectedNode(); n != nil && n.Module() != nil {
+				return m.editSource(n.Module(), 0, n.Name())
+			}
+			return m, nil
+		}},
 		contextSep(),
 	}
 
@@ -293,6 +307,8 @@ func resultMenuItems(m model) []contextMenuItem {
 	hasOID := hasResult && res.OID != ""
 
 	snmpReady := connected && idle && hasOID
+	g := m.results.history.Current()
+	resumable := connected && idle && g != nil && g.Partial
 
 	// Check if we can resolve the MIB node from this result
 	canJump := false
@@ -322,6 +338,9 @@ func resultMenuItems(m model) []contextMenuItem {
 			}
 			return m.startQueryWalk(r.OID)
 		})},
+		{label: "Resume Walk", key: "sr", enabled: resumable, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.resumeWalk()
+		}},
 		contextSep(),
 		{label: "Copy OID", key: "", enabled: hasOID, action: withSelectedResult(func(m model, r *snmp.Result) (tea.Model, tea.Cmd) {
 			return m, copyText(r.OID)
@@ -334,6 +353,12 @@ func resultMenuItems(m model) []contextMenuItem {
 			m.crossRefResultByOID(r.OID)
 			return m, nil
 		})},
+		{label: "Conformance Report", key: "vv", enabled: hasResult, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.conformanceReport()
+		}},
+		{label: "Draft AGENT-CAPABILITIES", key: "sa", enabled: idle && hasResult, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.draftCapabilities()
+		}},
 	}
 }
 
@@ -354,15 +379,33 @@ func detailMenuItems(m model) []contextMenuItem {
 
 func tableDataMenuItems(m model) []contextMenuItem {
 	hasRow := m.tableData.selectedRow() != nil
+	connected := m.snmp.IsConnected()
+	hasRowStatus := m.tableDataObj != nil && snmp.RowStatusColumn(m.tableDataObj) != nil
 
 	return []contextMenuItem{
+		{label: "New Row", key: "n", enabled: connected && hasRowStatus, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.openRowEditor(rowEditCreate)
+		}},
+		{label: "Edit Row", key: "e", enabled: connected && hasRow, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.openRowEditor(rowEditModify)
+		}},
+		{label: "Destroy Row", key: "D", enabled: connected && hasRow && hasRowStatus, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.openRowEditor(rowEditDestroy)
+		}},
+		{label: "Refresh", key: "r", enabled: connected && m.tableDataObj != nil, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.refreshTableData(false)
+		}},
+		{label: "Refresh Visible Columns", key: "R", enabled: connected && m.tableDataObj != nil, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.refreshTableData(true)
+		}},
+		contextSep(),
 		{label: "Copy Row", key: "", enabled: hasRow, action: func(m model) (tea.Model, tea.Cmd) {
 			row := m.tableData.selectedRow()
 			if row == nil {
 				return m, nil
 			}
 			var parts []string
-			for i, cell := range row {
+			for i, cell := range row.Cells {
 				col := ""
 				if i < len(m.tableData.columns) {
 					col = m.tableData.columns[i]
@@ -384,6 +427,93 @@ func watchMenuItems(m model) []contextMenuItem {
 	}
 }
 
+func ifaceMenuItems(m model) []contextMenuItem {
+	hasRow := m.ifaces.selected() != nil
+	return []contextMenuItem{
+		{label: "Watch Counters", key: "enter", enabled: hasRow, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.watchInterface()
+		}},
+		{label: "Next Sort Column", key: "o", enabled: true, action: func(m model) (tea.Model, tea.Cmd) {
+			m.ifaces.cycleSort()
+			return m.setStatusReturn(statusInfo, "Sort: "+m.ifaces.sortLabel())
+		}},
+		{label: "Reverse Sort", key: "O", enabled: true, action: func(m model) (tea.Model, tea.Cmd) {
+			m.ifaces.reverseSort()
+			return m.setStatusReturn(statusInfo, "Sort: "+m.ifaces.sortLabel())
+		}},
+		contextSep(),
+		{label: "Restart Polling", key: "r", enabled: m.snmp.IsConnected(), action: func(m model) (tea.Model, tea.Cmd) {
+			return m.interfaceDashboard()
+		}},
+		{label: "Pause Polling", key: "esc", enabled: m.ifaces.active, action: func(m model) (tea.Model, tea.Cmd) {
+			m.ifaces.stop()
+			m.focus = focusTree
+			return m, nil
+		}},
+	}
+}
+
+func inventoryMenuItems(m model) []contextMenuItem {
+	sel := m.inventory.lv.Selected()
+	hasKids := sel != nil && sel.hasKids
+	return []contextMenuItem{
+		{label: "Expand", key: "l", enabled: hasKids && !sel.ent.expanded, action: func(m model) (tea.Model, tea.Cmd) {
+			m.inventory.expand()
+			return m, nil
+		}},
+		{label: "Collapse", key: "h", enabled: hasKids && sel.ent.expanded, action: func(m model) (tea.Model, tea.Cmd) {
+			m.inventory.collapse()
+			return m, nil
+		}},
+		contextSep(),
+		{label: "Refresh", key: "r", enabled: m.snmp.IsConnected() && !m.inventory.loading, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.physicalInventory()
+		}},
+	}
+}
+
+func neighborMenuItems(m model) []contextMenuItem {
+	n := m.neighbors.selected()
+	canHop := n != nil && n.addr.IsValid()
+	_, hasPrev := m.neighbors.previous()
+	return []contextMenuItem{
+		{label: "Connect", key: "enter", enabled: canHop, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.hopToNeighbor()
+		}},
+		{label: "Connect With Profile", key: "p", enabled: canHop, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.hopWithProfile()
+		}},
+		{label: "Back One Hop", key: "b", enabled: hasPrev, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.hopBack()
+		}},
+		contextSep(),
+		{label: "Refresh", key: "r", enabled: m.snmp.IsConnected() && !m.neighbors.loading, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.readNeighbors()
+		}},
+	}
+}
+
+func scanMenuItems(m model) []contextMenuItem {
+	hasHit := m.scanList.selected() != nil
+	return []contextMenuItem{
+		{label: "Connect", key: "enter", enabled: hasHit, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.connectScanHit()
+		}},
+		{label: "Save Profile", key: "p", enabled: hasHit, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.saveScanHit()
+		}},
+		contextSep(),
+		{label: "Scan Again", key: "r", enabled: m.scan == nil, action: func(m model) (tea.Model, tea.Cmd) {
+			return m.startScan(m.scanList.prefix, m.scanList.profile)
+		}},
+		{label: "Cancel Scan", key: "esc", enabled: m.scan != nil, action: func(m model) (tea.Model, tea.Cmd) {
+			m.scan.Cancel()
+			m.scanList.cancelling = true
+			return m, nil
+		}},
+	}
+}
+
 // withSelectedResult wraps a context menu action that needs the currently
 // selected result. If no result is selected, it returns (m, nil).
 func withSelectedResult(fn func(model, *snmp.Result) (tea.Model, tea.Cmd)) func(model) (tea.Model, tea.Cmd) {
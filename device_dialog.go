@@ -3,7 +3,10 @@ package main
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/netip"
 	"slices"
+	"strconv"
 	"strings"
 
 	"charm.land/bubbles/v2/textinput"
@@ -25,6 +28,10 @@ const (
 	fieldAuthPass
 	fieldPrivProto
 	fieldPrivPass
+	fieldOIDOrder
+	fieldGentle
+	fieldRateLimit
+	fieldParallel
 )
 
 type dialogSection int
@@ -39,6 +46,12 @@ type deviceDialogSubmitMsg struct {
 	device profile.Device
 }
 
+// scanDialogSubmitMsg carries a prefix to sweep and the credentials to use.
+type scanDialogSubmitMsg struct {
+	prefix  string
+	profile snmp.Profile
+}
+
 // deviceDialogDeleteMsg requests removal of a saved profile.
 type deviceDialogDeleteMsg struct {
 	name string
@@ -121,10 +134,20 @@ type deviceDialogModel struct {
 	authPass  textinput.Model
 	privProto selectModel
 	privPass  textinput.Model
+	oidOrder  selectModel
+	gentle    selectModel
+	rateLimit textinput.Model
+	parallel  textinput.Model
 
 	section dialogSection // which section has focus
 	focused dialogField   // which field is focused (when section==sectionFields)
 	err     string
+
+	scan bool // subnet sweep: Target holds a prefix and enter starts the sweep
+
+	// Neighbor hop: saved profiles lend their credentials to hopTarget
+	hopName   string
+	hopTarget string
 }
 
 func newDeviceDialog(cfg appConfig, profiles []profile.Device) deviceDialogModel {
@@ -173,6 +196,15 @@ func newDeviceDialog(cfg appConfig, profiles []profile.Device) deviceDialogModel
 	privPass := mkInput("passphrase", 128, "")
 	privPass.EchoMode = textinput.EchoPassword
 	privPass.EchoCharacter = '*'
+	oidOrder := newSelect([]string{snmp.OIDOrderStrict, snmp.OIDOrderLenient})
+	oidOrder.SetValue(snmp.OIDOrderStrict)
+	if cfg.oidOrder != "" {
+		oidOrder.SetValue(cfg.oidOrder)
+	}
+	gentle := newSelect([]string{"off", "on"})
+	gentle.SetValue(onOff(cfg.gentle))
+	rateLimit := mkInput("req/s, blank for none", 8, formatRateLimit(cfg.rateLimit))
+	parallel := mkInput("4", 3, formatParallel(cfg.maxParallel))
 
 	d := deviceDialogModel{
 		profiles:  profiles,
@@ -185,6 +217,10 @@ func newDeviceDialog(cfg appConfig, profiles []profile.Device) deviceDialogModel
 		authPass:  authPass,
 		privProto: privProto,
 		privPass:  privPass,
+		oidOrder:  oidOrder,
+		gentle:    gentle,
+		rateLimit: rateLimit,
+		parallel:  parallel,
 		focused:   fieldTarget,
 	}
 
@@ -197,6 +233,63 @@ func newDeviceDialog(cfg appConfig, profiles []profile.Device) deviceDialogModel
 	return d
 }
 
+// newScanDialog returns the dialog in subnet sweep mode, with the
+// credentials of dev (the last connection, if any) filled in and the
+// target's /24 suggested as the prefix.
+func newScanDialog(cfg appConfig, dev profile.Device) deviceDialogModel {
+	d := newDeviceDialog(cfg, nil)
+	if dev.Target != "" {
+		d.fillFromProfile(dev)
+	}
+	d.scan = true
+	d.target.Placeholder = "CIDR[:port], e.g. 10.1.2.0/24"
+	d.target.SetValue(suggestPrefix(d.target.Value()))
+	return d
+}
+
+// newHopDialog returns the dialog for connecting to a neighbor at target,
+// with the credentials of dev (the current connection) filled in. Choosing
+// a saved profile uses its credentials for the neighbor.
+func newHopDialog(cfg appConfig, profiles []profile.Device, dev profile.Device, name, target string) deviceDialogModel {
+	d := newDeviceDialog(cfg, profiles)
+	if dev.Target != "" {
+		d.fillFromProfile(dev)
+	}
+	d.hopName = name
+	d.hopTarget = target
+	d.target.SetValue(target)
+	return d
+}
+
+// hop points a saved profile at the neighbor being connected to, when the
+// dialog was opened for one.
+func (d *deviceDialogModel) hop(dev profile.Device) profile.Device {
+	if d.hopTarget == "" {
+		return dev
+	}
+	dev.Name = d.hopName
+	dev.Target = d.hopTarget
+	return dev
+}
+
+// suggestPrefix returns the /24 around an IPv4 target, or "". A port on
+// the target is kept, so agents on the same non-standard port are found.
+func suggestPrefix(target string) string {
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		host, port = target, ""
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !addr.Is4() {
+		return ""
+	}
+	prefix := netip.PrefixFrom(addr, 24).Masked().String()
+	if port != "" && port != "161" {
+		prefix += ":" + port
+	}
+	return prefix
+}
+
 // isV3 returns true if the current version field value indicates SNMPv3.
 func (d *deviceDialogModel) isV3() bool {
 	return d.version.Value() == "3"
@@ -204,6 +297,12 @@ func (d *deviceDialogModel) isV3() bool {
 
 // visibleFields returns the list of active fields based on version and security level.
 func (d *deviceDialogModel) visibleFields() []dialogField {
+	return append(d.credentialFields(), fieldOIDOrder, fieldGentle, fieldRateLimit, fieldParallel)
+}
+
+// credentialFields returns the target and credential fields for the
+// current version and security level.
+func (d *deviceDialogModel) credentialFields() []dialogField {
 	if !d.isV3() {
 		return []dialogField{fieldTarget, fieldCommunity, fieldVersion}
 	}
@@ -249,6 +348,14 @@ func (d *deviceDialogModel) fieldInput(f dialogField) dialogInput {
 		return dialogInput{sel: &d.privProto}
 	case fieldPrivPass:
 		return dialogInput{text: &d.privPass}
+	case fieldOIDOrder:
+		return dialogInput{sel: &d.oidOrder}
+	case fieldGentle:
+		return dialogInput{sel: &d.gentle}
+	case fieldRateLimit:
+		return dialogInput{text: &d.rateLimit}
+	case fieldParallel:
+		return dialogInput{text: &d.parallel}
 	}
 	return dialogInput{}
 }
@@ -273,6 +380,14 @@ func fieldLabel(f dialogField) string {
 		return "Priv Proto:"
 	case fieldPrivPass:
 		return "Priv Pass:"
+	case fieldOIDOrder:
+		return "OID Order:"
+	case fieldGentle:
+		return "Gentle:"
+	case fieldRateLimit:
+		return "Rate Limit:"
+	case fieldParallel:
+		return "Parallel:"
 	}
 	return ""
 }
@@ -294,6 +409,10 @@ func (d *deviceDialogModel) blurAll() {
 	d.authPass.Blur()
 	d.privProto.Blur()
 	d.privPass.Blur()
+	d.oidOrder.Blur()
+	d.gentle.Blur()
+	d.rateLimit.Blur()
+	d.parallel.Blur()
 }
 
 func (d *deviceDialogModel) cycleForward() tea.Cmd {
@@ -351,12 +470,27 @@ func (d *deviceDialogModel) fillFromProfile(p profile.Device) {
 	d.authPass.SetValue(p.AuthPass)
 	d.privProto.SetValue(p.PrivProto)
 	d.privPass.SetValue(p.PrivPass)
+	d.oidOrder.SetValue(snmp.OIDOrderStrict)
+	if p.OIDOrder != "" {
+		d.oidOrder.SetValue(p.OIDOrder)
+	}
+	d.gentle.SetValue(onOff(p.Gentle))
+	d.rateLimit.SetValue(formatRateLimit(p.RateLimit))
+	d.parallel.SetValue(formatParallel(p.MaxParallel))
 }
 
 func (d *deviceDialogModel) validate() error {
 	if strings.TrimSpace(d.target.Value()) == "" {
+		if d.scan {
+			return errors.New("subnet is required")
+		}
 		return errors.New("target is required")
 	}
+	if d.scan {
+		if _, err := snmp.ScanHosts(d.target.Value()); err != nil {
+			return err
+		}
+	}
 	if _, err := snmp.ParseVersion(d.version.Value()); err != nil {
 		return err
 	}
@@ -365,13 +499,23 @@ func (d *deviceDialogModel) validate() error {
 			return errors.New("username is required for v3")
 		}
 	}
+	if _, err := parseRateLimit(d.rateLimit.Value()); err != nil {
+		return err
+	}
+	if _, err := parseParallel(d.parallel.Value()); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (d *deviceDialogModel) device() profile.Device {
 	target := strings.TrimSpace(d.target.Value())
+	name := target
+	if d.hopTarget != "" && target == d.hopTarget {
+		name = d.hopName
+	}
 	p := profile.Device{
-		Name: target,
+		Name: name,
 		Profile: snmp.Profile{
 			Target:  target,
 			Version: d.version.Value(),
@@ -387,11 +531,69 @@ func (d *deviceDialogModel) device() profile.Device {
 	} else {
 		p.Community = strings.TrimSpace(d.community.Value())
 	}
+	if v := d.oidOrder.Value(); v != snmp.OIDOrderStrict {
+		p.OIDOrder = v
+	}
+	p.Gentle = d.gentle.Value() == "on"
+	p.RateLimit, _ = parseRateLimit(d.rateLimit.Value())
+	p.MaxParallel, _ = parseParallel(d.parallel.Value())
 	return p
 }
 
+// parseRateLimit reads a requests-per-second limit; blank means none.
+func parseRateLimit(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return 0, errors.New("rate limit must be a number of requests per second")
+	}
+	return v, nil
+}
+
+// parseParallel reads a parallel connection count; blank means the default.
+func parseParallel(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 1 {
+		return 0, errors.New("parallel must be a whole number of at least 1")
+	}
+	return v, nil
+}
+
+func formatRateLimit(v float64) string {
+	if v <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func formatParallel(v int) string {
+	if v <= 0 {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
 func (d *deviceDialogModel) submitCmd() tea.Cmd {
 	dev := d.device()
+	if d.scan {
+		return func() tea.Msg {
+			return scanDialogSubmitMsg{prefix: dev.Target, profile: dev.Profile}
+		}
+	}
 	return func() tea.Msg {
 		return deviceDialogSubmitMsg{device: dev}
 	}
@@ -423,7 +625,7 @@ func (d *deviceDialogModel) updateProfiles(msg tea.KeyPressMsg) (tea.Cmd, bool)
 		return nil, false
 	case "enter":
 		if d.profileIdx >= 0 && d.profileIdx < len(d.profiles) {
-			dev := d.profiles[d.profileIdx]
+			dev := d.hop(d.profiles[d.profileIdx])
 			return func() tea.Msg {
 				return deviceDialogSubmitMsg{device: dev}
 			}, true
@@ -432,7 +634,7 @@ func (d *deviceDialogModel) updateProfiles(msg tea.KeyPressMsg) (tea.Cmd, bool)
 	case "tab":
 		// Switch to fields, pre-fill from selected profile
 		if d.profileIdx >= 0 && d.profileIdx < len(d.profiles) {
-			d.fillFromProfile(d.profiles[d.profileIdx])
+			d.fillFromProfile(d.hop(d.profiles[d.profileIdx]))
 		}
 		d.section = sectionFields
 		d.focused = d.visibleFields()[0]
@@ -519,7 +721,13 @@ func (d *deviceDialogModel) view() string {
 	var b strings.Builder
 	bg := palette.BgLighter
 
-	title := styles.Dialog.Title.Background(bg).Render("Connect to Device")
+	titleText := "Connect to Device"
+	if d.scan {
+		titleText = "Scan Subnet"
+	} else if d.hopTarget != "" {
+		titleText = "Connect to Neighbor " + d.hopName
+	}
+	title := styles.Dialog.Title.Background(bg).Render(titleText)
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
@@ -552,7 +760,11 @@ func (d *deviceDialogModel) view() string {
 	for _, f := range fields {
 		di := d.fieldInput(f)
 		active := d.section == sectionFields && d.focused == f
-		lbl := styles.Label.Background(bg).Render(fmt.Sprintf("%-*s", labelW, fieldLabel(f)))
+		label := fieldLabel(f)
+		if d.scan && f == fieldTarget {
+			label = "Subnet:"
+		}
+		lbl := styles.Label.Background(bg).Render(fmt.Sprintf("%-*s", labelW, label))
 		b.WriteString(lbl)
 		if active {
 			b.WriteString(di.activeView())
@@ -582,8 +794,12 @@ func (d *deviceDialogModel) view() string {
 		b.WriteString(keyStyle.Render("del") + valStyle.Render("remove profile") + "\n")
 		b.WriteString(keyStyle.Render("esc") + valStyle.Render("cancel"))
 	} else {
+		action := "connect"
+		if d.scan {
+			action = "scan"
+		}
 		b.WriteString(keyStyle.Render("tab") + valStyle.Render("next field") + "\n")
-		b.WriteString(keyStyle.Render("enter") + valStyle.Render("connect") + "\n")
+		b.WriteString(keyStyle.Render("enter") + valStyle.Render(action) + "\n")
 		b.WriteString(keyStyle.Render("esc") + valStyle.Render("cancel"))
 	}
 
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// enterprisesOID is the private enterprises arc vendors register under.
+var enterprisesOID = mib.OID{1, 3, 6, 1, 4, 1}
+
+// vendorInfo names a private enterprise and the modules that register its
+// products and describe its devices, product registrations first.
+type vendorInfo struct {
+	name    string
+	modules []string
+}
+
+// knownVendors maps private enterprise numbers to vendors whose MIBs are
+// commonly needed. Enterprises not listed are named from the loaded MIBs.
+var knownVendors = map[uint32]vendorInfo{
+	9:     {"Cisco", []string{"CISCO-PRODUCTS-MIB", "CISCO-SMI", "CISCO-PROCESS-MIB", "CISCO-MEMORY-POOL-MIB"}},
+	11:    {"HPE", []string{"HP-ICF-OID"}},
+	171:   {"D-Link", nil},
+	311:   {"Microsoft", nil},
+	318:   {"APC", []string{"PowerNet-MIB"}},
+	674:   {"Dell", nil},
+	1916:  {"Extreme", []string{"EXTREME-BASE-MIB"}},
+	1991:  {"Brocade", []string{"FOUNDRY-SN-ROOT-MIB"}},
+	2011:  {"Huawei", []string{"HUAWEI-MIB"}},
+	2021:  {"UCD-SNMP", []string{"UCD-SNMP-MIB"}},
+	2620:  {"Check Point", []string{"CHECKPOINT-MIB"}},
+	2636:  {"Juniper", []string{"JUNIPER-CHASSIS-DEFINES-MIB", "JUNIPER-SMI", "JUNIPER-MIB"}},
+	3375:  {"F5", []string{"F5-BIGIP-COMMON-MIB", "F5-BIGIP-SYSTEM-MIB"}},
+	4526:  {"Netgear", nil},
+	6486:  {"Alcatel-Lucent Enterprise", []string{"ALCATEL-IND1-BASE"}},
+	6527:  {"Nokia", []string{"TIMETRA-GLOBAL-MIB", "TIMETRA-CHASSIS-MIB"}},
+	6876:  {"VMware", []string{"VMWARE-PRODUCTS-MIB", "VMWARE-ROOT-MIB"}},
+	8072:  {"Net-SNMP", []string{"NET-SNMP-TC", "NET-SNMP-MIB", "UCD-SNMP-MIB"}},
+	12356: {"Fortinet", []string{"FORTINET-FORTIGATE-MIB", "FORTINET-CORE-MIB"}},
+	14988: {"MikroTik", []string{"MIKROTIK-MIB"}},
+	25461: {"Palo Alto Networks", []string{"PAN-PRODUCTS-MIB", "PAN-GLOBAL-REG"}},
+	30065: {"Arista", []string{"ARISTA-PRODUCTS-MIB", "ARISTA-SMI-MIB"}},
+	41112: {"Ubiquiti", []string{"UBNT-MIB"}},
+}
+
+// deviceIdentity is what an agent's sysObjectID says about the device.
+type deviceIdentity struct {
+	objectID   string   // sysObjectID, dotted
+	enterprise uint32   // private enterprise number, 0 outside enterprises
+	vendor     string   // vendor name, "" when unknown
+	model      string   // product registration name, "" if not in the loaded MIBs
+	module     string   // module registering the product
+	suggest    []string // vendor modules that are not loaded
+}
+
+// identifyDevice resolves a sysObjectID through the loaded MIBs. The model
+// is the name of the product registration OID, when a loaded module
+// defines it; the vendor comes from the enterprise number.
+func identifyDevice(objectID string, m *mib.Mib) deviceIdentity {
+	id := deviceIdentity{objectID: objectID}
+	oid, err := mib.ParseOID(objectID)
+	if err != nil || len(oid) == 0 {
+		return id
+	}
+
+	if node := m.NodeByOID(oid); node != nil {
+		id.model = node.Name()
+		if mod := node.Module(); mod != nil {
+			id.module = mod.Name()
+		}
+	}
+
+	if len(oid) <= len(enterprisesOID) || !oid.HasPrefix(enterprisesOID) {
+		return id
+	}
+	id.enterprise = oid[len(enterprisesOID)]
+	if len(oid) == len(enterprisesOID)+1 {
+		id.model = "" // the enterprise itself, not a product
+	}
+
+	vendor, known := knownVendors[id.enterprise]
+	if known {
+		id.vendor = vendor.name
+	} else if node := m.NodeByOID(oid[:len(enterprisesOID)+1]); node != nil {
+		id.vendor = node.Name()
+	}
+	for _, name := range vendor.modules {
+		if m.Module(name) == nil {
+			id.suggest = append(id.suggest, name)
+		}
+	}
+	return id
+}
+
+// label describes the device for the header: vendor and model where known,
+// otherwise whatever can be said about the sysObjectID.
+func (id deviceIdentity) label() string {
+	vendor := id.vendor
+	if vendor == "" && id.enterprise != 0 {
+		vendor = fmt.Sprintf("enterprise %d", id.enterprise)
+	}
+	switch {
+	case vendor != "" && id.model != "":
+		return vendor + " " + id.model
+	case vendor != "":
+		return vendor
+	case id.model != "":
+		return id.model
+	default:
+		return id.objectID
+	}
+}
+
+// suggestion returns a hint naming vendor modules to load when the model
+// could not be named, or "".
+func (id deviceIdentity) suggestion() string {
+	if id.model != "" || len(id.suggest) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("load %s to name %s", strings.Join(id.suggest, ", "), id.objectID)
+}
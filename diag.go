@@ -135,7 +135,7 @@ func (d *diagModel) view() string {
 	b.WriteByte('\n')
 
 	// Line 2: status bar
-	status := fmt.Sprintf("severity: %s  (%d/%d)  [tab] cycle",
+	status := fmt.Sprintf("severity: %s  (%d/%d)  [tab] cycle  [ctrl+o] source  [ctrl+e] edit",
 		d.severityLabel(), d.lv.Len(), len(d.all))
 	if d.unresolved > 0 {
 		status += fmt.Sprintf("  unresolved: %d", d.unresolved)
@@ -164,7 +164,11 @@ func (d *diagModel) renderDiagRow(diag mib.Diagnostic, _ int, selected bool, wid
 func (d *diagModel) renderDiag(diag mib.Diagnostic) string {
 	sevText := "[" + diag.Severity.String() + "]"
 	sev := padRight(diagSeverityStyle(diag.Severity).Render(sevText), 9)
+	return sev + " " + styles.Value.Render(diagText(diag))
+}
 
+// diagText formats a diagnostic as "MODULE:LINE:COL: message (code)".
+func diagText(diag mib.Diagnostic) string {
 	var loc string
 	if diag.Module != "" {
 		loc = diag.Module
@@ -181,6 +185,5 @@ func (d *diagModel) renderDiag(diag mib.Diagnostic) string {
 	if diag.Code != "" {
 		msg += " (" + diag.Code + ")"
 	}
-
-	return sev + " " + styles.Value.Render(msg)
+	return msg
 }
@@ -77,6 +77,16 @@ func (el *expandableList) toggleExpand() bool {
 	return true
 }
 
+// selectedItem returns the index of the item under the cursor, or -1 if
+// there is none.
+func (el *expandableList) selectedItem() int {
+	sel := el.lv.Selected()
+	if sel == nil {
+		return -1
+	}
+	return sel.itemIdx
+}
+
 // resetExpanded clears all expanded state.
 func (el *expandableList) resetExpanded() {
 	el.expanded = make(map[int]bool)
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// fileEntry is one row of the file picker.
+type fileEntry struct {
+	name string
+	dir  bool
+}
+
+// filePickerModel browses the filesystem for a MIB file or directory to
+// load. Follows the xrefPickerModel pattern.
+type filePickerModel struct {
+	lv     ListView[fileEntry]
+	dir    string // directory being listed, kept between openings
+	err    string // why dir could not be listed
+	active bool
+	width  int
+	height int
+}
+
+func newFilePicker() filePickerModel {
+	return filePickerModel{
+		lv: NewListView[fileEntry](2), // header + hint
+	}
+}
+
+// activate opens the picker on dir, or on the last directory shown.
+func (fp *filePickerModel) activate(dir string) {
+	fp.active = true
+	if fp.dir == "" {
+		fp.open(dir)
+	} else {
+		fp.open(fp.dir)
+	}
+}
+
+func (fp *filePickerModel) deactivate() {
+	fp.active = false
+}
+
+func (fp *filePickerModel) setSize(width, height int) {
+	fp.width = width
+	fp.height = height
+	fp.lv.SetSize(width, height)
+}
+
+// open lists dir: directories first, then files, each sorted by name.
+// Hidden entries are skipped.
+func (fp *filePickerModel) open(dir string) {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	fp.dir = dir
+	fp.err = ""
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		fp.err = err.Error()
+	}
+	var rows []fileEntry
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		isDir := e.IsDir()
+		if e.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(dir, e.Name())); err == nil {
+				isDir = info.IsDir()
+			}
+		}
+		rows = append(rows, fileEntry{name: e.Name(), dir: isDir})
+	}
+	slices.SortFunc(rows, func(a, b fileEntry) int {
+		if a.dir != b.dir {
+			if a.dir {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+	})
+	fp.lv.SetRows(rows)
+	fp.lv.GoTop()
+}
+
+// parent moves up one directory with the cursor on the one just left.
+func (fp *filePickerModel) parent() {
+	child := filepath.Base(fp.dir)
+	up := filepath.Dir(fp.dir)
+	if up == fp.dir {
+		return
+	}
+	fp.open(up)
+	for i, e := range fp.lv.Rows() {
+		if e.name == child {
+			fp.lv.SetCursor(i)
+			break
+		}
+	}
+}
+
+// selectedPath returns the full path of the entry under the cursor.
+func (fp *filePickerModel) selectedPath() (string, bool) {
+	sel := fp.lv.Selected()
+	if sel == nil {
+		return "", false
+	}
+	return filepath.Join(fp.dir, sel.name), sel.dir
+}
+
+// Cursor delegation: bridges navigablePane interface to ListView.
+func (fp *filePickerModel) cursorUp()   { fp.lv.CursorUp() }
+func (fp *filePickerModel) cursorDown() { fp.lv.CursorDown() }
+func (fp *filePickerModel) goTop()      { fp.lv.GoTop() }
+func (fp *filePickerModel) goBottom()   { fp.lv.GoBottom() }
+func (fp *filePickerModel) pageUp()     { fp.lv.PageUp() }
+func (fp *filePickerModel) pageDown()   { fp.lv.PageDown() }
+
+func (fp *filePickerModel) view() string {
+	if !fp.active {
+		return ""
+	}
+
+	var b strings.Builder
+
+	// Hint line
+	hint := "enter:open/load  space:load  bksp:up  esc:close"
+	b.WriteString(styles.StatusText.Render(hint))
+	b.WriteByte('\n')
+
+	// Header
+	header := fmt.Sprintf("Load MIBs from %s", fp.dir)
+	b.WriteString(styles.Header.Info.Render(truncate(header, fp.width)))
+	b.WriteByte('\n')
+
+	// List
+	switch {
+	case fp.err != "":
+		b.WriteString(styles.Status.ErrorMsg.Render(fp.err))
+	case fp.lv.Len() == 0:
+		b.WriteString(styles.EmptyText.Render("(empty directory)"))
+	default:
+		b.WriteString(fp.lv.Render(fileEntryRenderFunc))
+	}
+
+	return b.String()
+}
+
+func fileEntryRenderFunc(entry fileEntry, _ int, selected bool, width int) string {
+	var styled string
+	if entry.dir {
+		styled = styles.Label.Render(entry.name + "/")
+	} else {
+		styled = styles.Value.Render(entry.name)
+	}
+
+	if selected {
+		return renderSelectedRow(styled, width)
+	}
+	return "  " + styled
+}
@@ -16,7 +16,7 @@ var filterFields = func() []string {
 		"display_hint", "language",
 		"is_tc", "is_table", "is_row", "is_column", "is_scalar",
 		"is_counter", "is_gauge", "is_string", "is_enum", "is_bits",
-		"arc", "depth",
+		"implemented", "arc", "depth",
 	}
 	slices.Sort(f)
 	return f
@@ -268,6 +268,8 @@ func renderFilterHelp() string {
 		[]string{"is_counter", "is_gauge", "is_string", "is_enum", "is_bits"},
 		val, "  ",
 	))
+	b.WriteString("\n")
+	b.WriteString("  " + val.Render("implemented") + " " + lbl.Render("(agent answered, after s d)"))
 	b.WriteString("\n\n")
 
 	// Numeric fields
@@ -327,6 +329,8 @@ func renderFilterHelp() string {
 	b.WriteString(val.Render("  is_counter or is_gauge"))
 	b.WriteString("\n")
 	b.WriteString(val.Render(`  module == "IF-MIB" and depth < 10`))
+	b.WriteString("\n")
+	b.WriteString(val.Render(`  implemented and is_table`))
 
 	return b.String()
 }
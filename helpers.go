@@ -220,6 +220,16 @@ func isIconClick(baseX, x, depth int, hasKids bool) bool {
 	return x >= iconX && x < iconX+2
 }
 
+// violationFlag returns the inline marker for an out-of-spec value,
+// truncated to room columns, or "" when the value conforms or there is no
+// room for it.
+func violationFlag(violation string, room int) string {
+	if violation == "" || room < 3 {
+		return ""
+	}
+	return truncate("  "+IconWarn+" "+violation, room)
+}
+
 // truncate shortens s so its visual width fits within maxW, appending a
 // unicode ellipsis if truncated. Uses lipgloss.Width for visual-width
 // measurement, handling wide and multi-byte characters correctly.
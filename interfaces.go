@@ -0,0 +1,581 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+const ifaceHeaderLines = 3 // header + column headers + separator
+
+// IF-MIB columns the interface dashboard polls, walked by OID so it works
+// whether or not IF-MIB is loaded. ifXTable supplies names, aliases, 64-bit
+// octet counters and speeds above 4 Gbit/s; ifTable is the fallback.
+const (
+	oidIfDescr        = "1.3.6.1.2.1.2.2.1.2"
+	oidIfSpeed        = "1.3.6.1.2.1.2.2.1.5"
+	oidIfInOctets     = "1.3.6.1.2.1.2.2.1.10"
+	oidIfInUcastPkts  = "1.3.6.1.2.1.2.2.1.11"
+	oidIfInDiscards   = "1.3.6.1.2.1.2.2.1.13"
+	oidIfInErrors     = "1.3.6.1.2.1.2.2.1.14"
+	oidIfOutOctets    = "1.3.6.1.2.1.2.2.1.16"
+	oidIfOutUcastPkts = "1.3.6.1.2.1.2.2.1.17"
+	oidIfOutDiscards  = "1.3.6.1.2.1.2.2.1.19"
+	oidIfOutErrors    = "1.3.6.1.2.1.2.2.1.20"
+
+	oidIfName           = "1.3.6.1.2.1.31.1.1.1.1"
+	oidIfHCInOctets     = "1.3.6.1.2.1.31.1.1.1.6"
+	oidIfHCInUcastPkts  = "1.3.6.1.2.1.31.1.1.1.7"
+	oidIfHCOutOctets    = "1.3.6.1.2.1.31.1.1.1.10"
+	oidIfHCOutUcastPkts = "1.3.6.1.2.1.31.1.1.1.11"
+	oidIfHighSpeed      = "1.3.6.1.2.1.31.1.1.1.15"
+	oidIfAlias          = "1.3.6.1.2.1.31.1.1.1.18"
+)
+
+// ifaceOIDs returns the columns an interface poll walks. The 32-bit octet
+// counters are only walked when legacy is set.
+func ifaceOIDs(legacy bool) []string {
+	oids := []string{
+		oidIfDescr, oidIfSpeed, oidIfAdminStatus, oidIfOperStatus,
+		oidIfInErrors, oidIfOutErrors, oidIfInDiscards, oidIfOutDiscards,
+		oidIfName, oidIfAlias, oidIfHighSpeed, oidIfHCInOctets, oidIfHCOutOctets,
+	}
+	if legacy {
+		oids = append(oids, oidIfInOctets, oidIfOutOctets)
+	}
+	return oids
+}
+
+// ifaceTickMsg signals that the next interface poll should start.
+type ifaceTickMsg struct {
+	seq uint64 // ignored if stale
+}
+
+// ifaceRow is one interface as of the latest poll. Rates are -1 until two
+// polls have been made.
+type ifaceRow struct {
+	index   int64  // ifIndex
+	suffix  string // instance suffix, the ifIndex as text
+	name    string // ifName, or ifDescr for agents without ifXTable
+	alias   string
+	admin   int64
+	oper    int64
+	speed   float64 // bits per second, 0 if unknown
+	hc      bool    // octet counts come from the 64-bit counters
+	inPct   float64 // input utilisation
+	outPct  float64 // output utilisation
+	errRate float64 // input and output errors per second
+	dscRate float64 // input and output discards per second
+}
+
+// ifaceSortColumns names the dashboard columns, in display order, for
+// sorting.
+var ifaceSortColumns = []string{"IDX", "NAME", "ALIAS", "ADMIN", "OPER", "SPEED", "IN%", "OUT%", "ERR/s", "DISC/s"}
+
+// ifaceModel polls ifTable and ifXTable and shows one row per interface
+// with utilisation and error rates computed between polls.
+type ifaceModel struct {
+	active   bool
+	target   string
+	interval time.Duration
+	pollSeq  uint64 // monotonic counter, incremented on start/stop
+	pollNum  int
+	polling  bool
+
+	prev   watchSnapshot        // counter values from the previous poll
+	prevAt map[string]time.Time // when each column was read in the previous poll
+	rated  bool                 // the latest poll had a previous one to compute rates from
+	rows   []ifaceRow
+	hasX   bool // the agent returned ifXTable
+	legacy bool // some interface lacked 64-bit octet counters last poll
+
+	sortCol  int // index into ifaceSortColumns
+	sortDesc bool
+
+	lv    ListView[ifaceRow]
+	width int
+}
+
+func newIfaceModel() ifaceModel {
+	return ifaceModel{
+		interval: watchDefaultInterval,
+		lv:       NewListView[ifaceRow](ifaceHeaderLines),
+	}
+}
+
+// start begins polling the interfaces of target, discarding rows and
+// counters from any earlier poll.
+func (f *ifaceModel) start(target string) {
+	f.active = true
+	f.target = target
+	f.pollSeq++
+	f.pollNum = 0
+	f.polling = false
+	f.prev = nil
+	f.rows = nil
+	f.hasX = false
+	f.legacy = false
+	f.lv.SetRows(nil)
+}
+
+// resume restarts polling of the same target after a pause, keeping the
+// rows. Rates restart from the next poll.
+func (f *ifaceModel) resume() {
+	f.active = true
+	f.pollSeq++
+	f.polling = false
+	f.prev = nil
+}
+
+// stop ends polling and invalidates any in-flight polls or ticks.
+func (f *ifaceModel) stop() {
+	f.active = false
+	f.pollSeq++
+	f.polling = false
+}
+
+// adjustInterval changes the poll interval by delta, clamped like the watch.
+func (f *ifaceModel) adjustInterval(delta time.Duration) {
+	f.interval = min(max(f.interval+delta, watchMinInterval), watchMaxInterval)
+}
+
+// pollLabel is the CollectCmd label of the current poll.
+func (f *ifaceModel) pollLabel() string {
+	return fmt.Sprintf("interfaces %s #%d", f.target, f.pollSeq)
+}
+
+// startPollCmd returns a command to begin a poll if none is in flight.
+// The first poll walks both octet counter sizes to learn which the agent
+// has; later ones walk the 32-bit counters only if an interface needs them.
+func (f *ifaceModel) startPollCmd(sess *snmp.Session) tea.Cmd {
+	if !f.active || f.polling {
+		return nil
+	}
+	f.polling = true
+	return snmp.CollectCmd(sess, f.pollLabel(), ifaceOIDs(f.pollNum == 0 || f.legacy), 0)
+}
+
+// scheduleNextTick returns a command to schedule the next poll.
+func (f *ifaceModel) scheduleNextTick() tea.Cmd {
+	if !f.active {
+		return nil
+	}
+	seq := f.pollSeq
+	return tea.Tick(f.interval, func(time.Time) tea.Msg {
+		return ifaceTickMsg{seq: seq}
+	})
+}
+
+// handlePoll joins the polled columns into rows, computing each counter's
+// rate over the time between the reads of its column in this poll and the
+// previous one.
+func (f *ifaceModel) handlePoll(pdus map[string][]gosnmp.SnmpPDU, at map[string]time.Time, m *mib.Mib) {
+	f.pollNum++
+	f.polling = false
+
+	cols := make(map[string]map[string]gosnmp.SnmpPDU)
+	col := func(oid string) map[string]gosnmp.SnmpPDU {
+		c, ok := cols[oid]
+		if !ok {
+			c = summaryColumn(pdus, oid)
+			cols[oid] = c
+		}
+		return c
+	}
+	descr, name, alias := col(oidIfDescr), col(oidIfName), col(oidIfAlias)
+	admin, oper := col(oidIfAdminStatus), col(oidIfOperStatus)
+	speed, highSpeed := col(oidIfSpeed), col(oidIfHighSpeed)
+	hcIn := col(oidIfHCInOctets)
+	f.hasX = len(name) > 0 || len(hcIn) > 0
+
+	// Interfaces are whatever ifIndex values any of the identifying
+	// columns returned.
+	all := make(map[string]gosnmp.SnmpPDU)
+	for _, c := range []map[string]gosnmp.SnmpPDU{descr, name, oper} {
+		for suffix, pdu := range c {
+			all[suffix] = pdu
+		}
+	}
+
+	text := func(c map[string]gosnmp.SnmpPDU, suffix string) string {
+		pdu, ok := c[suffix]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(snmp.FormatPDUToResult(pdu, m).Value)
+	}
+	integer := func(c map[string]gosnmp.SnmpPDU, suffix string) int64 {
+		v, _ := snmp.IntegerValue(c[suffix])
+		return v
+	}
+
+	rated := false
+	curr := make(watchSnapshot)
+	rate := func(oid, suffix string) (float64, bool) {
+		pdu, ok := col(oid)[suffix]
+		if !ok {
+			return 0, false
+		}
+		v, ok := snmp.ExtractNumeric(pdu)
+		if !ok {
+			return 0, false
+		}
+		curr[pdu.Name] = v
+		p, had := f.prev[pdu.Name]
+		if !had {
+			return 0, false
+		}
+		secs := at[oid].Sub(f.prevAt[oid]).Seconds()
+		if secs <= 0 {
+			return 0, false
+		}
+		rated = true
+		return computeDelta(p, v, pdu.Type) / secs, true
+	}
+	// sum adds the in and out rates of a counter pair, -1 when neither
+	// could be computed.
+	sum := func(in, out, suffix string) float64 {
+		a, okA := rate(in, suffix)
+		b, okB := rate(out, suffix)
+		if !okA && !okB {
+			return -1
+		}
+		return a + b
+	}
+
+	legacy := false
+	rows := make([]ifaceRow, 0, len(all))
+	for _, suffix := range sortedSuffixes(all) {
+		r := ifaceRow{
+			suffix: suffix,
+			name:   text(name, suffix),
+			alias:  text(alias, suffix),
+			admin:  integer(admin, suffix),
+			oper:   integer(oper, suffix),
+			inPct:  -1,
+			outPct: -1,
+		}
+		r.index, _ = strconv.ParseInt(suffix, 10, 64)
+		if r.name == "" {
+			r.name = text(descr, suffix)
+		}
+		// IF-MIB: ifSpeed is exact; ifHighSpeed is rounded to whole Mbps
+		// and only meant for when ifSpeed saturates at its maximum.
+		r.speed = float64(integer(speed, suffix))
+		if r.speed == math.MaxUint32 {
+			if hs := integer(highSpeed, suffix); hs > 0 {
+				r.speed = float64(hs) * 1e6
+			}
+		}
+
+		in, out := oidIfInOctets, oidIfOutOctets
+		if _, ok := hcIn[suffix]; ok {
+			in, out, r.hc = oidIfHCInOctets, oidIfHCOutOctets, true
+		} else {
+			legacy = true
+		}
+		if v, ok := rate(in, suffix); ok && r.speed > 0 {
+			r.inPct = v * 8 / r.speed * 100
+		}
+		if v, ok := rate(out, suffix); ok && r.speed > 0 {
+			r.outPct = v * 8 / r.speed * 100
+		}
+		r.errRate = sum(oidIfInErrors, oidIfOutErrors, suffix)
+		r.dscRate = sum(oidIfInDiscards, oidIfOutDiscards, suffix)
+		rows = append(rows, r)
+	}
+
+	f.rated = rated
+	f.legacy = legacy
+	f.prev = curr
+	f.prevAt = at
+	f.rows = rows
+	f.applySort()
+}
+
+// applySort orders the rows by the sort column, keeping the cursor on the
+// same interface.
+func (f *ifaceModel) applySort() {
+	sel := ""
+	if r := f.lv.Selected(); r != nil {
+		sel = r.suffix
+	}
+	slices.SortStableFunc(f.rows, f.compareRows)
+	f.lv.SetRows(f.rows)
+	if i := slices.IndexFunc(f.rows, func(r ifaceRow) bool { return r.suffix == sel }); i >= 0 {
+		f.lv.SetCursor(i)
+	}
+}
+
+// compareRows orders two rows by the sort column, then by ifIndex.
+func (f *ifaceModel) compareRows(a, b ifaceRow) int {
+	var c int
+	switch f.sortCol {
+	case 1:
+		c = strings.Compare(a.name, b.name)
+	case 2:
+		c = strings.Compare(a.alias, b.alias)
+	case 3:
+		c = cmp.Compare(a.admin, b.admin)
+	case 4:
+		c = cmp.Compare(a.oper, b.oper)
+	case 5:
+		c = cmp.Compare(a.speed, b.speed)
+	case 6:
+		c = cmp.Compare(a.inPct, b.inPct)
+	case 7:
+		c = cmp.Compare(a.outPct, b.outPct)
+	case 8:
+		c = cmp.Compare(a.errRate, b.errRate)
+	case 9:
+		c = cmp.Compare(a.dscRate, b.dscRate)
+	}
+	if c == 0 {
+		c = cmp.Compare(a.index, b.index)
+	}
+	if f.sortDesc {
+		return -c
+	}
+	return c
+}
+
+// cycleSort moves the sort to the next column, ascending.
+func (f *ifaceModel) cycleSort() {
+	f.sortCol = (f.sortCol + 1) % len(ifaceSortColumns)
+	f.sortDesc = false
+	f.applySort()
+}
+
+// reverseSort flips the sort direction.
+func (f *ifaceModel) reverseSort() {
+	f.sortDesc = !f.sortDesc
+	f.applySort()
+}
+
+// sortLabel describes the current sort for status messages.
+func (f *ifaceModel) sortLabel() string {
+	dir := "ascending"
+	if f.sortDesc {
+		dir = "descending"
+	}
+	return ifaceSortColumns[f.sortCol] + " " + dir
+}
+
+// selected returns the interface under the cursor, or nil.
+func (f *ifaceModel) selected() *ifaceRow {
+	return f.lv.Selected()
+}
+
+// counterOIDs returns the instance OIDs of an interface's status and
+// counters, for a watch.
+func (f *ifaceModel) counterOIDs(r ifaceRow) []string {
+	cols := []string{oidIfOperStatus, oidIfInOctets, oidIfOutOctets, oidIfInUcastPkts, oidIfOutUcastPkts}
+	if r.hc {
+		cols = []string{oidIfOperStatus, oidIfHCInOctets, oidIfHCOutOctets, oidIfHCInUcastPkts, oidIfHCOutUcastPkts}
+	}
+	cols = append(cols, oidIfInErrors, oidIfOutErrors, oidIfInDiscards, oidIfOutDiscards)
+	oids := make([]string, len(cols))
+	for i, c := range cols {
+		oids[i] = c + "." + r.suffix
+	}
+	return oids
+}
+
+func (f *ifaceModel) setSize(width, height int) {
+	f.width = width
+	f.lv.SetSize(width, height)
+}
+
+func (f *ifaceModel) clickRow(row int) {
+	if row >= 0 && row < f.lv.Len() {
+		f.lv.SetCursor(row)
+	}
+}
+
+// view renders the interfaces pane content.
+func (f *ifaceModel) view() string {
+	if f.target == "" {
+		return styles.EmptyText.Render("(no interfaces polled)")
+	}
+
+	var b strings.Builder
+
+	header := fmt.Sprintf("INTERFACES %s (%s)", f.target, formatInterval(f.interval))
+	switch {
+	case f.pollNum == 0:
+		header += " | polling..."
+	case !f.active:
+		header += fmt.Sprintf(" | %d interfaces | paused", len(f.rows))
+	default:
+		header += fmt.Sprintf(" | %d interfaces | poll #%d", len(f.rows), f.pollNum)
+		if !f.rated {
+			header += " | rates after next poll"
+		}
+	}
+	if f.pollNum > 0 && !f.hasX {
+		header += " | no ifXTable: 32-bit counters"
+	}
+	b.WriteString(styles.Header.Info.Render(header))
+	b.WriteByte('\n')
+
+	if len(f.rows) == 0 {
+		if f.pollNum == 0 {
+			b.WriteString(styles.EmptyText.Render(IconLoading + " Waiting for first poll..."))
+		} else {
+			b.WriteString(styles.EmptyText.Render("(the agent returned no interfaces)"))
+		}
+		return b.String()
+	}
+
+	idxW, nameW, aliasW, adminW, operW, speedW, pctW, rateW := 5, 12, 8, 7, 7, 7, 6, 8
+	for _, r := range f.rows {
+		idxW = max(idxW, len(r.suffix))
+		nameW = max(nameW, lipgloss.Width(r.name))
+		aliasW = max(aliasW, lipgloss.Width(r.alias))
+		operW = max(operW, len(ifStatusName(r.oper)))
+	}
+	idxW = min(idxW, 10)
+	nameW = min(nameW, 24)
+	others := idxW + nameW + adminW + operW + speedW + 2*pctW + 2*rateW
+	gaps := 2 * (len(ifaceSortColumns) - 1)
+	aliasW = max(5, min(aliasW, 32, f.width-others-gaps-2))
+
+	widths := []int{idxW, nameW, aliasW, adminW, operW, speedW, pctW, pctW, rateW, rateW}
+	var hdr strings.Builder
+	hdr.WriteString("  ")
+	for i, name := range ifaceSortColumns {
+		if i == f.sortCol {
+			if f.sortDesc {
+				name += "▾"
+			} else {
+				name += "▴"
+			}
+		}
+		if i > 0 {
+			hdr.WriteString("  ")
+		}
+		if i >= 5 {
+			fmt.Fprintf(&hdr, "%*s", widths[i], name)
+		} else {
+			fmt.Fprintf(&hdr, "%-*s", widths[i], name)
+		}
+	}
+	b.WriteString(styles.Header.Info.Render(truncate(hdr.String(), f.width)))
+	b.WriteByte('\n')
+
+	sepW := others + aliasW + gaps
+	b.WriteString("  " + styles.Table.Sep.Render(strings.Repeat("─", max(0, min(sepW, f.width-2)))))
+	b.WriteByte('\n')
+
+	vis := f.lv.VisibleRows()
+	offset := f.lv.Offset()
+	cursor := f.lv.Cursor()
+	end := min(offset+vis, len(f.rows))
+
+	for i := offset; i < end; i++ {
+		r := f.rows[i]
+
+		var line strings.Builder
+		if i == cursor {
+			line.WriteString(selectedBorder() + " ")
+		} else {
+			line.WriteString("  ")
+		}
+
+		line.WriteString(styles.Table.Index.Render(fmt.Sprintf("%-*s", idxW, truncate(r.suffix, idxW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Value.Render(fmt.Sprintf("%-*s", nameW, truncate(r.name, nameW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Label.Render(fmt.Sprintf("%-*s", aliasW, truncate(r.alias, aliasW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Label.Render(fmt.Sprintf("%-*s", adminW, truncate(ifStatusName(r.admin), adminW))))
+		line.WriteString("  ")
+		operStyle := styles.Label
+		switch {
+		case r.oper == 1:
+			operStyle = styles.Status.SuccessMsg
+		case r.admin == 1:
+			operStyle = styles.Status.ErrorMsg // enabled but not up
+		}
+		line.WriteString(operStyle.Render(fmt.Sprintf("%-*s", operW, truncate(ifStatusName(r.oper), operW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Value.Render(fmt.Sprintf("%*s", speedW, formatSpeed(r.speed))))
+		line.WriteString("  ")
+		line.WriteString(renderUtil(r.inPct, pctW))
+		line.WriteString("  ")
+		line.WriteString(renderUtil(r.outPct, pctW))
+		line.WriteString("  ")
+		line.WriteString(renderErrRate(r.errRate, rateW))
+		line.WriteString("  ")
+		line.WriteString(renderErrRate(r.dscRate, rateW))
+
+		b.WriteString(line.String())
+		if i < end-1 {
+			b.WriteByte('\n')
+		}
+	}
+
+	return attachScrollbar(b.String(), vis, len(f.rows), vis, offset)
+}
+
+// ifStatusName labels an ifAdminStatus or ifOperStatus value.
+func ifStatusName(v int64) string {
+	if name, ok := ifOperStatusNames[v]; ok {
+		return name
+	}
+	if v == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+// formatSpeed formats an interface speed in bits per second.
+func formatSpeed(bps float64) string {
+	switch {
+	case bps <= 0:
+		return "-"
+	case bps >= 1e9:
+		return strconv.FormatFloat(bps/1e9, 'f', -1, 64) + "G"
+	case bps >= 1e6:
+		return strconv.FormatFloat(bps/1e6, 'f', -1, 64) + "M"
+	case bps >= 1e3:
+		return strconv.FormatFloat(bps/1e3, 'f', -1, 64) + "k"
+	}
+	return strconv.FormatFloat(bps, 'f', -1, 64)
+}
+
+// renderUtil renders a utilisation percentage, highlighting busy links.
+func renderUtil(pct float64, width int) string {
+	if pct < 0 {
+		return styles.Label.Render(fmt.Sprintf("%*s", width, "-"))
+	}
+	padded := fmt.Sprintf("%*.1f", width, pct)
+	switch {
+	case pct >= 90:
+		return styles.Status.ErrorMsg.Render(padded)
+	case pct >= 70:
+		return styles.Status.WarnMsg.Render(padded)
+	}
+	return styles.Value.Render(padded)
+}
+
+// renderErrRate renders an error or discard rate, highlighting any.
+func renderErrRate(r float64, width int) string {
+	switch {
+	case r < 0:
+		return styles.Label.Render(fmt.Sprintf("%*s", width, "-"))
+	case r == 0:
+		return styles.Label.Render(fmt.Sprintf("%*s", width, "0"))
+	}
+	return styles.Status.ErrorMsg.Render(fmt.Sprintf("%*s", width, formatRate(r, 1)))
+}
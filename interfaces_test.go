@@ -0,0 +1,50 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// ifacePoll builds a poll of one 10 Mbit/s interface whose 64-bit input
+// octet counter reads in, with the counter column read at at.
+func ifacePoll(in uint64, at time.Time) (map[string][]gosnmp.SnmpPDU, map[string]time.Time) {
+	pdus := map[string][]gosnmp.SnmpPDU{
+		oidIfName:       {{Name: "." + oidIfName + ".1", Type: gosnmp.OctetString, Value: []byte("eth0")}},
+		oidIfSpeed:      {{Name: "." + oidIfSpeed + ".1", Type: gosnmp.Gauge32, Value: uint(10_000_000)}},
+		oidIfHCInOctets: {{Name: "." + oidIfHCInOctets + ".1", Type: gosnmp.Counter64, Value: in}},
+	}
+	at0 := at.Add(-time.Hour) // other columns read long before; must not matter
+	return pdus, map[string]time.Time{oidIfName: at0, oidIfSpeed: at0, oidIfHCInOctets: at}
+}
+
+func TestIfacePollRates(t *testing.T) {
+	m := loadTestMib(t)
+	f := newIfaceModel()
+	f.start("192.0.2.1")
+	if !slices.Contains(ifaceOIDs(f.pollNum == 0 || f.legacy), oidIfInOctets) {
+		t.Error("first poll does not walk the 32-bit counters")
+	}
+
+	t0 := time.Now()
+	pdus, at := ifacePoll(0, t0)
+	f.handlePoll(pdus, at, m)
+	if f.legacy {
+		t.Error("legacy set for an interface with 64-bit counters")
+	}
+	if slices.Contains(ifaceOIDs(f.pollNum == 0 || f.legacy), oidIfInOctets) {
+		t.Error("later polls walk the 32-bit counters although the 64-bit ones answered")
+	}
+
+	// 6.25 MB in 10 s is 5 Mbit/s, half of the interface speed.
+	pdus, at = ifacePoll(6_250_000, t0.Add(10*time.Second))
+	f.handlePoll(pdus, at, m)
+	if len(f.rows) != 1 {
+		t.Fatalf("rows = %d, want 1", len(f.rows))
+	}
+	if got := f.rows[0].inPct; got != 50 {
+		t.Errorf("inPct = %v, want 50", got)
+	}
+}
@@ -24,6 +24,9 @@ func (p Device) Summary() string {
 	if p.IsV3() {
 		s += ", " + p.Username
 	}
+	if p.Gentle {
+		s += ", gentle"
+	}
 	return s
 }
 
@@ -0,0 +1,323 @@
+package snmp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
+)
+
+// berShownOctets caps how much of an octet string is shown in a TLV line.
+const berShownOctets = 24
+
+// BERNode is one tag-length-value element of a decoded SNMP message.
+type BERNode struct {
+	Offset    int    // position of the tag octet in the message
+	Tag       byte   // identifier octet
+	HeaderLen int    // tag and length octets
+	Length    int    // declared content length
+	Content   []byte // content octets actually present
+	Children  []BERNode
+	Problem   string // why decoding stopped here, "" if the element is intact
+}
+
+// Constructed reports whether the element contains other elements.
+func (n BERNode) Constructed() bool {
+	return n.Tag&0x20 != 0
+}
+
+// DecodeBER splits a message into its TLV structure. Decoding is lenient:
+// a truncated or malformed element is returned with Problem set and with
+// whatever content is present, and nothing after it is decoded.
+func DecodeBER(b []byte) []BERNode {
+	return decodeTLVs(b, 0)
+}
+
+func decodeTLVs(b []byte, base int) []BERNode {
+	var nodes []BERNode
+	for pos := 0; pos < len(b); {
+		n := decodeTLV(b[pos:], base+pos)
+		nodes = append(nodes, n)
+		if n.Problem != "" {
+			break
+		}
+		pos += n.HeaderLen + n.Length
+	}
+	return nodes
+}
+
+func decodeTLV(b []byte, offset int) BERNode {
+	n := BERNode{Offset: offset, Tag: b[0], HeaderLen: len(b)}
+	if len(b) < 2 {
+		n.Problem = "truncated before length"
+		return n
+	}
+	hdr, length := 2, int(b[1])
+	switch {
+	case b[1] == 0x80:
+		n.Problem = "indefinite length is not allowed in SNMP"
+		return n
+	case b[1] > 0x80:
+		k := int(b[1] & 0x7f)
+		if k > 4 {
+			n.Problem = fmt.Sprintf("%d-octet length field", k)
+			return n
+		}
+		if 2+k > len(b) {
+			n.Problem = "truncated inside length"
+			return n
+		}
+		length = 0
+		for _, c := range b[2 : 2+k] {
+			length = length<<8 | int(c)
+		}
+		hdr += k
+	}
+	n.HeaderLen = hdr
+	n.Length = length
+	n.Content = b[hdr:min(hdr+length, len(b))]
+	if hdr+length > len(b) {
+		n.Problem = fmt.Sprintf("truncated: %d of %d content octets", len(n.Content), length)
+	}
+	if n.Constructed() {
+		n.Children = decodeTLVs(n.Content, offset+hdr)
+	}
+	return n
+}
+
+// TagName names the element's type.
+func (n BERNode) TagName() string {
+	switch {
+	case n.Tag == byte(gosnmp.Sequence):
+		return "SEQUENCE"
+	case n.Tag >= byte(gosnmp.GetRequest) && n.Tag <= byte(gosnmp.Report):
+		return gosnmp.PDUType(n.Tag).String()
+	default:
+		return pduTypeName(gosnmp.Asn1BER(n.Tag))
+	}
+}
+
+// Describe renders a primitive element's value, naming OIDs through the
+// MIB when one is given. Constructed elements describe as "".
+func (n BERNode) Describe(m *mib.Mib) string {
+	if n.Constructed() {
+		return ""
+	}
+	c := n.Content
+	switch gosnmp.Asn1BER(n.Tag) {
+	case gosnmp.Integer:
+		return strconv.FormatInt(berInt(c), 10)
+	case gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		return strconv.FormatUint(berUint(c), 10)
+	case gosnmp.ObjectIdentifier:
+		oid := berOID(c)
+		if name := formatOID(oid, m); name != oid {
+			return oid + " (" + name + ")"
+		}
+		return oid
+	case gosnmp.IPAddress:
+		if len(c) == 4 {
+			return fmt.Sprintf("%d.%d.%d.%d", c[0], c[1], c[2], c[3])
+		}
+	case gosnmp.OctetString, gosnmp.Opaque:
+		if len(c) == 0 {
+			return `""`
+		}
+		shown, more := c, ""
+		if len(shown) > berShownOctets {
+			shown, more = shown[:berShownOctets], "..."
+		}
+		if utf8.Valid(c) && isPrintable(c) {
+			return strconv.Quote(string(shown)) + more
+		}
+		var b strings.Builder
+		writeHex(&b, shown)
+		return b.String() + more
+	}
+	return ""
+}
+
+// berInt decodes a two's complement INTEGER.
+func berInt(c []byte) int64 {
+	var v int64
+	if len(c) > 0 && c[0]&0x80 != 0 {
+		v = -1
+	}
+	for _, x := range c {
+		v = v<<8 | int64(x)
+	}
+	return v
+}
+
+// berUint decodes an unsigned application type.
+func berUint(c []byte) uint64 {
+	var v uint64
+	for _, x := range c {
+		v = v<<8 | uint64(x)
+	}
+	return v
+}
+
+// berOID decodes OBJECT IDENTIFIER content to dotted form.
+func berOID(c []byte) string {
+	var b strings.Builder
+	var v uint64
+	first := true
+	for _, x := range c {
+		v = v<<7 | uint64(x&0x7f)
+		if x&0x80 != 0 {
+			continue
+		}
+		if first {
+			arc := min(v/40, 2)
+			writeUint(&b, arc, 10)
+			b.WriteByte('.')
+			writeUint(&b, v-arc*40, 10)
+			first = false
+		} else {
+			b.WriteByte('.')
+			writeUint(&b, v, 10)
+		}
+		v = 0
+	}
+	return b.String()
+}
+
+// PacketInfo is the header of an SNMP message, read from its BER without
+// depending on gosnmp's decoder, so truncated and malformed messages still
+// show as much as arrived.
+type PacketInfo struct {
+	Version     string         // "1", "2c" or "3"
+	MsgID       int64          // SNMPv3 msgID
+	PDUType     gosnmp.PDUType // zero when the PDU could not be located
+	RequestID   int64
+	ErrorStatus int64 // non-repeaters for GetBulkRequest
+	ErrorIndex  int64 // max-repetitions for GetBulkRequest
+	VarBinds    []string
+	Encrypted   bool   // SNMPv3 scoped PDU is encrypted
+	Problem     string // first decoding problem, "" for a well-formed message
+}
+
+// ParsePacket reads the header fields of an SNMP message.
+func ParsePacket(b []byte) PacketInfo {
+	var p PacketInfo
+	if len(b) == 0 {
+		p.Problem = "empty message"
+		return p
+	}
+	nodes := DecodeBER(b)
+	p.Problem = firstProblem(nodes)
+	if len(nodes) > 1 && p.Problem == "" {
+		p.Problem = fmt.Sprintf("%d trailing octets", len(b)-nodes[0].HeaderLen-nodes[0].Length)
+	}
+	msg := nodes[0]
+	if msg.Tag != byte(gosnmp.Sequence) || len(msg.Children) < 2 {
+		if p.Problem == "" {
+			p.Problem = "not an SNMP message"
+		}
+		return p
+	}
+
+	var pdu *BERNode
+	switch berInt(msg.Children[0].Content) {
+	case 0:
+		p.Version = "1"
+	case 1:
+		p.Version = "2c"
+	case 3:
+		p.Version = "3"
+	}
+	if p.Version == "3" {
+		if hdr := msg.Children[1]; len(hdr.Children) > 0 {
+			p.MsgID = berInt(hdr.Children[0].Content)
+		}
+		if len(msg.Children) > 3 {
+			scoped := msg.Children[3]
+			switch {
+			case scoped.Tag == byte(gosnmp.OctetString):
+				p.Encrypted = true
+			case len(scoped.Children) > 2:
+				pdu = &scoped.Children[2]
+			}
+		}
+	} else if len(msg.Children) > 2 {
+		pdu = &msg.Children[2]
+	}
+	if pdu == nil || pdu.Tag < byte(gosnmp.GetRequest) || pdu.Tag > byte(gosnmp.Report) {
+		return p
+	}
+
+	p.PDUType = gosnmp.PDUType(pdu.Tag)
+	if p.PDUType == gosnmp.Trap {
+		return p // SNMPv1 trap layout has no request-id
+	}
+	fields := pdu.Children
+	if len(fields) > 0 {
+		p.RequestID = berInt(fields[0].Content)
+	}
+	if len(fields) > 1 {
+		p.ErrorStatus = berInt(fields[1].Content)
+	}
+	if len(fields) > 2 {
+		p.ErrorIndex = berInt(fields[2].Content)
+	}
+	if len(fields) > 3 {
+		for _, vb := range fields[3].Children {
+			if len(vb.Children) > 0 && vb.Children[0].Tag == byte(gosnmp.ObjectIdentifier) && vb.Children[0].Problem == "" {
+				p.VarBinds = append(p.VarBinds, berOID(vb.Children[0].Content))
+			}
+		}
+	}
+	return p
+}
+
+// ErrorName returns the name of the error-status, e.g. "genErr".
+func (p PacketInfo) ErrorName() string {
+	if p.ErrorStatus < 0 || p.ErrorStatus > 255 {
+		return "error " + strconv.FormatInt(p.ErrorStatus, 10)
+	}
+	name := gosnmp.SNMPError(p.ErrorStatus).String()
+	if strings.HasPrefix(name, "SNMPError(") {
+		return "error " + strconv.FormatInt(p.ErrorStatus, 10)
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// VarBindName names the varbind at a 1-based error-index through the MIB,
+// returning "" when the index does not point into the varbind list.
+func (p PacketInfo) VarBindName(index int64, m *mib.Mib) string {
+	if index < 1 || index > int64(len(p.VarBinds)) {
+		return ""
+	}
+	return formatOID(p.VarBinds[index-1], m)
+}
+
+// firstProblem returns the first decoding problem in document order.
+func firstProblem(nodes []BERNode) string {
+	for _, n := range nodes {
+		if n.Problem != "" {
+			return fmt.Sprintf("%s at offset %d", n.Problem, n.Offset)
+		}
+		if p := firstProblem(n.Children); p != "" {
+			return p
+		}
+	}
+	return ""
+}
+
+// messageKey returns the identifier a response shares with its request:
+// the msgID for SNMPv3, otherwise the request-id.
+func messageKey(b []byte) (int64, bool) {
+	p := ParsePacket(b)
+	switch {
+	case p.Version == "3":
+		return p.MsgID, true
+	case p.PDUType != 0:
+		return p.RequestID, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,55 @@
+package snmp
+
+import (
+	"errors"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/gosnmp/gosnmp"
+)
+
+// errCollectLimit stops a walk once enough instances have been collected.
+var errCollectLimit = errors.New("collect limit reached")
+
+// CollectMsg carries the instances the agent returned under each OID of a
+// CollectCmd, keyed by the requested OID.
+type CollectMsg struct {
+	Label string
+	PDUs  map[string][]gosnmp.SnmpPDU
+	At    map[string]time.Time // when each OID was read, midway through its walk
+	Err   error
+}
+
+// CollectCmd walks each OID in turn, keeping at most limit instances per
+// OID (0 for no limit). OIDs the agent has nothing under map to nil. The
+// label is echoed back so the caller can match the result to its request.
+func CollectCmd(sess *Session, label string, oids []string, limit int) tea.Cmd {
+	return func() tea.Msg {
+		if !sess.IsConnected() {
+			return CollectMsg{Label: label, Err: errors.New("not connected")}
+		}
+
+		pdus := make(map[string][]gosnmp.SnmpPDU, len(oids))
+		at := make(map[string]time.Time, len(oids))
+		for _, oid := range oids {
+			var got []gosnmp.SnmpPDU
+			start := time.Now()
+			err := doWalk(sess, oid, func(pdu gosnmp.SnmpPDU) error {
+				if !HasValue(pdu) {
+					return nil
+				}
+				got = append(got, pdu)
+				if limit > 0 && len(got) >= limit {
+					return errCollectLimit
+				}
+				return nil
+			})
+			pdus[oid] = got
+			at[oid] = start.Add(time.Since(start) / 2)
+			if err != nil && !errors.Is(err, errCollectLimit) {
+				return CollectMsg{Label: label, PDUs: pdus, At: at, Err: err}
+			}
+		}
+		return CollectMsg{Label: label, PDUs: pdus, At: at}
+	}
+}
@@ -0,0 +1,93 @@
+package snmp
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
+)
+
+// CompareOIDStrings orders two dotted OID strings arc by arc, so "1.10"
+// sorts after "1.9". Unparseable values fall back to string order.
+func CompareOIDStrings(a, b string) int {
+	oa, errA := mib.ParseOID(a)
+	ob, errB := mib.ParseOID(b)
+	if errA != nil || errB != nil {
+		return cmp.Compare(a, b)
+	}
+	return slices.Compare(oa, ob)
+}
+
+// ComparePDU orders two varbind values by type-aware comparison. Numeric
+// types compare by value, so enumerations sort by number rather than label.
+// Octet strings and IP addresses compare bytewise, OIDs arc by arc. Absent
+// values (zero PDUs) and SNMP exceptions sort after everything else.
+func ComparePDU(a, b gosnmp.SnmpPDU) int {
+	aAbsent, bAbsent := !HasValue(a), !HasValue(b)
+	switch {
+	case aAbsent && bAbsent:
+		return 0
+	case aAbsent:
+		return 1
+	case bAbsent:
+		return -1
+	}
+
+	if a.Type == gosnmp.Counter64 && b.Type == gosnmp.Counter64 {
+		return gosnmp.ToBigInt(a.Value).Cmp(gosnmp.ToBigInt(b.Value))
+	}
+	if na, ok := ExtractNumeric(a); ok {
+		if nb, ok := ExtractNumeric(b); ok {
+			return cmp.Compare(na, nb)
+		}
+	}
+
+	if a.Type == b.Type {
+		switch a.Type {
+		case gosnmp.OctetString, gosnmp.Opaque:
+			ba, _ := a.Value.([]byte)
+			bb, _ := b.Value.([]byte)
+			return bytes.Compare(ba, bb)
+		case gosnmp.IPAddress:
+			return bytes.Compare(ipBytes(a.Value), ipBytes(b.Value))
+		case gosnmp.ObjectIdentifier:
+			sa, _ := a.Value.(string)
+			sb, _ := b.Value.(string)
+			return CompareOIDStrings(trimDot(sa), trimDot(sb))
+		}
+	}
+	return cmp.Compare(fmt.Sprint(a.Value), fmt.Sprint(b.Value))
+}
+
+// HasValue reports whether a PDU carries a usable value, as opposed to a
+// zero PDU for a missing cell or an SNMP exception.
+func HasValue(pdu gosnmp.SnmpPDU) bool {
+	if pdu.Name == "" {
+		return false
+	}
+	switch pdu.Type {
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null:
+		return false
+	}
+	return true
+}
+
+func ipBytes(v any) []byte {
+	if s, ok := v.(string); ok {
+		if ip := net.ParseIP(s).To4(); ip != nil {
+			return ip
+		}
+	}
+	return nil
+}
+
+func trimDot(s string) string {
+	if len(s) > 0 && s[0] == '.' {
+		return s[1:]
+	}
+	return s
+}
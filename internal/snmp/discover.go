@@ -0,0 +1,162 @@
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
+)
+
+// sysORTableOID is SNMPv2-MIB::sysORTable.
+const sysORTableOID = "1.3.6.1.2.1.1.9"
+
+// sysOREntry column arcs within sysORTable.1.
+const (
+	sysORIDArc    = 2
+	sysORDescrArc = 3
+)
+
+// probeBatchSize is the number of GETNEXT varbinds sent per request when
+// probing subtree roots. Kept small so SNMPv1 agents don't answer tooBig.
+const probeBatchSize = 10
+
+// SysOREntry is one row of the agent's sysORTable.
+type SysOREntry struct {
+	Index string // row index
+	ID    string // sysORID, dotted OID
+	Descr string // sysORDescr
+}
+
+// DiscoverMsg carries the result of an agent capability discovery.
+type DiscoverMsg struct {
+	SysOR    []SysOREntry
+	Probed   int      // number of subtree roots probed
+	Answered []string // probed roots (dotted OIDs) the agent returned data under
+	Err      error
+}
+
+// DiscoverCmd reads sysORTable and probes each root with GETNEXT, recording
+// the roots under which the agent returned at least one instance. A missing
+// sysORTable is not an error; agents are not required to populate it.
+func DiscoverCmd(sess *Session, roots []mib.OID) tea.Cmd {
+	return func() tea.Msg {
+		if !sess.IsConnected() {
+			return DiscoverMsg{Err: errors.New("not connected")}
+		}
+
+		sysOR := readSysOR(sess)
+
+		var answered []string
+		for start := 0; start < len(roots); start += probeBatchSize {
+			batch := roots[start:min(start+probeBatchSize, len(roots))]
+			hits, err := probeBatch(sess, batch)
+			if err != nil {
+				return DiscoverMsg{SysOR: sysOR, Probed: start, Answered: answered, Err: err}
+			}
+			answered = append(answered, hits...)
+		}
+
+		return DiscoverMsg{SysOR: sysOR, Probed: len(roots), Answered: answered}
+	}
+}
+
+// probeBatch sends one GETNEXT for a batch of roots and returns those the
+// agent answered under. An SNMPv1 agent fails the whole request with
+// noSuchName when any varbind runs off the end of its MIB view, so a
+// PDU-level error falls back to probing each root on its own. A single
+// root answered with noSuchName is simply not implemented.
+func probeBatch(sess *Session, batch []mib.OID) ([]string, error) {
+	oids := make([]string, len(batch))
+	for i, r := range batch {
+		oids[i] = r.String()
+	}
+	pkt, err := exchange(sess, "discover", func(client *gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error) {
+		return client.GetNext(oids)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []string
+	if err := packetError(pkt); err != nil {
+		if len(batch) == 1 {
+			var se *Error
+			if errors.As(err, &se) && se.Kind == KindNoSuchName {
+				return nil, nil
+			}
+			return nil, err
+		}
+		for _, r := range batch {
+			h, err := probeBatch(sess, []mib.OID{r})
+			if err != nil {
+				return hits, err
+			}
+			hits = append(hits, h...)
+		}
+		return hits, nil
+	}
+	for i, pdu := range pkt.Variables {
+		if i < len(batch) && answersUnder(pdu, batch[i]) {
+			hits = append(hits, oids[i])
+		}
+	}
+	return hits, nil
+}
+
+// readSysOR walks sysORTable, returning its rows in walk order.
+func readSysOR(sess *Session) []SysOREntry {
+	colPrefix := func(arc int) string { return fmt.Sprintf(".%s.1.%d.", sysORTableOID, arc) }
+	idPrefix, descrPrefix := colPrefix(sysORIDArc), colPrefix(sysORDescrArc)
+
+	rows := make(map[string]*SysOREntry)
+	var order []string
+	row := func(idx string) *SysOREntry {
+		if e, ok := rows[idx]; ok {
+			return e
+		}
+		e := &SysOREntry{Index: idx}
+		rows[idx] = e
+		order = append(order, idx)
+		return e
+	}
+
+	_ = doWalk(sess, sysORTableOID, func(pdu gosnmp.SnmpPDU) error {
+		name := pdu.Name
+		if !strings.HasPrefix(name, ".") {
+			name = "." + name
+		}
+		switch {
+		case strings.HasPrefix(name, idPrefix):
+			if s, ok := pdu.Value.(string); ok {
+				row(name[len(idPrefix):]).ID = strings.TrimPrefix(s, ".")
+			}
+		case strings.HasPrefix(name, descrPrefix):
+			if b, ok := pdu.Value.([]byte); ok {
+				row(name[len(descrPrefix):]).Descr = string(b)
+			}
+		}
+		return nil
+	})
+
+	entries := make([]SysOREntry, 0, len(order))
+	for _, idx := range order {
+		entries = append(entries, *rows[idx])
+	}
+	return entries
+}
+
+// answersUnder reports whether a GETNEXT response lies within root.
+func answersUnder(pdu gosnmp.SnmpPDU, root mib.OID) bool {
+	switch pdu.Type {
+	case gosnmp.EndOfMibView, gosnmp.NoSuchObject, gosnmp.NoSuchInstance:
+		return false
+	}
+	oid, err := mib.ParseOID(pdu.Name)
+	if err != nil || len(oid) <= len(root) {
+		return false
+	}
+	return oid.HasPrefix(root)
+}
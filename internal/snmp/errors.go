@@ -0,0 +1,225 @@
+package snmp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
+)
+
+// ErrorKind groups SNMP failures by what the user can do about them.
+type ErrorKind int
+
+const (
+	KindOther         ErrorKind = iota
+	KindTimeout                 // no response at all
+	KindUnreachable             // ICMP unreachable, nothing listening
+	KindTooBig                  // response would not fit in one message
+	KindNoSuchName              // SNMPv1 noSuchName
+	KindAuthorization           // authorizationError, noAccess
+	KindNotWritable             // notWritable, readOnly
+	KindBadValue                // a SET value the agent rejected
+	KindAgentFailure            // genErr and other internal failures
+	KindReport                  // SNMPv3 report PDU (USM rejected the request)
+	KindDecryption              // SNMPv3 privacy failure
+	KindNotIncreasing           // a walk got an OID that does not follow the last
+)
+
+// Error is an SNMP failure explained for people who don't read RFC 3416:
+// a short statement of what went wrong, the varbind it concerns, and the
+// usual reasons it happens.
+type Error struct {
+	Kind    ErrorKind
+	Summary string   // what went wrong, in plain words
+	VarBind string   // OID of the varbind the agent blamed, "" if none
+	Causes  []string // likely causes, most likely first
+	Err     error    // the underlying gosnmp error
+}
+
+func (e *Error) Error() string {
+	if e.VarBind != "" {
+		return e.Summary + " (" + e.VarBind + ")"
+	}
+	return e.Summary
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Describe is Error with the varbind named through the MIB.
+func (e *Error) Describe(m *mib.Mib) string {
+	if e.VarBind != "" {
+		return e.Summary + ": " + formatOID(e.VarBind, m)
+	}
+	return e.Summary
+}
+
+// Detail returns the underlying error text, which is what the agent or
+// the network actually said.
+func (e *Error) Detail() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
+// ErrorText renders any error for display, explaining SNMP errors and
+// naming their varbind through the MIB.
+func ErrorText(err error, m *mib.Mib) string {
+	var se *Error
+	if errors.As(err, &se) {
+		return se.Describe(m)
+	}
+	return err.Error()
+}
+
+// classify turns an error from gosnmp into an *Error. Cancellation, limits
+// the caller set itself, and already classified errors pass through.
+func (s *Session) classify(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, errCollectLimit) {
+		return err
+	}
+	var se *Error
+	if errors.As(err, &se) {
+		return err
+	}
+	v3 := s.Version == "3"
+	e := &Error{Kind: KindOther, Err: err}
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		e.Kind, e.Summary = KindUnreachable, "nothing is listening for SNMP on the target port"
+		e.Causes = []string{
+			"the SNMP agent is not running",
+			"wrong port (agents normally listen on 161)",
+			"a host firewall rejects the port",
+		}
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		e.Kind, e.Summary = KindUnreachable, "the target cannot be reached"
+		e.Causes = []string{
+			"the device is down or the address is wrong",
+			"no route from this machine to the device's network",
+		}
+	case errors.Is(err, gosnmp.ErrDecryption):
+		e.Kind, e.Summary = KindDecryption, "the agent could not decrypt the request"
+		e.Causes = []string{
+			"wrong privacy passphrase",
+			"privacy protocol differs from the agent's (DES vs AES, AES key size)",
+		}
+	case errors.Is(err, gosnmp.ErrUnknownUsername):
+		e.Kind, e.Summary = KindReport, "the agent does not know this SNMPv3 user"
+		e.Causes = []string{
+			"user name misspelled, or not created on the agent",
+			"user exists only for a different engine ID",
+		}
+	case errors.Is(err, gosnmp.ErrWrongDigest):
+		e.Kind, e.Summary = KindReport, "SNMPv3 authentication failed"
+		e.Causes = []string{
+			"wrong authentication passphrase",
+			"authentication protocol differs from the agent's (MD5 vs SHA variants)",
+		}
+	case errors.Is(err, gosnmp.ErrUnknownSecurityLevel):
+		e.Kind, e.Summary = KindReport, "the user is not configured for this security level"
+		e.Causes = []string{
+			"choose the level the user was created with (noAuthNoPriv, authNoPriv, authPriv)",
+		}
+	case errors.Is(err, gosnmp.ErrNotInTimeWindow), errors.Is(err, gosnmp.ErrUnknownEngineID):
+		e.Kind, e.Summary = KindReport, "SNMPv3 engine discovery did not complete"
+		e.Causes = []string{
+			"the agent's engine boots or time changed mid-session; reconnect",
+			"several agents share one address behind NAT",
+		}
+	case errors.Is(err, gosnmp.ErrUnknownSecurityModels), errors.Is(err, gosnmp.ErrInvalidMsgs),
+		errors.Is(err, gosnmp.ErrUnknownPDUHandlers), errors.Is(err, gosnmp.ErrUnknownReportPDU):
+		e.Kind, e.Summary = KindReport, "the agent rejected the request with a report"
+		e.Causes = []string{"the agent's SNMPv3 configuration does not accept this request"}
+	case isTimeout(err):
+		e.Kind, e.Summary = KindTimeout, "the agent did not answer"
+		if v3 {
+			e.Causes = []string{
+				"a firewall or agent ACL drops requests from this address",
+				"wrong port, or the agent is not running",
+				"the agent is overloaded; try a longer timeout",
+			}
+		} else {
+			e.Causes = []string{
+				"wrong community string (agents drop these silently)",
+				"a firewall or agent ACL drops requests from this address",
+				"the agent does not accept SNMPv" + s.Version,
+				"wrong port, or the agent is not running",
+			}
+		}
+	default:
+		e.Summary = err.Error()
+	}
+	return e
+}
+
+// isTimeout reports whether err is a request that got no answer. gosnmp
+// wraps the socket's deadline error in some paths and reports its own
+// retry exhaustion as text in others, so the message is a fallback.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return strings.Contains(err.Error(), "timeout")
+}
+
+// packetError classifies a response whose error-status is set, or returns
+// nil if it is not.
+func packetError(pkt *gosnmp.SnmpPacket) error {
+	if pkt == nil || pkt.Error == gosnmp.NoError {
+		return nil
+	}
+	status := PacketInfo{ErrorStatus: int64(pkt.Error)}.ErrorName()
+	e := &Error{Err: errors.New(status)}
+	if i := int(pkt.ErrorIndex); i >= 1 && i <= len(pkt.Variables) {
+		e.VarBind = strings.TrimPrefix(pkt.Variables[i-1].Name, ".")
+	}
+	switch pkt.Error {
+	case gosnmp.TooBig:
+		e.Kind, e.Summary = KindTooBig, "the response would be larger than the agent can send"
+		e.Causes = []string{
+			"too many varbinds in one request; ask for fewer at a time",
+			"a very large value, such as a long octet string",
+		}
+	case gosnmp.NoSuchName:
+		e.Kind, e.Summary = KindNoSuchName, "the agent has no such object"
+		e.Causes = []string{
+			"the object is not implemented by this agent",
+			"the community's MIB view excludes it",
+			"for GETNEXT: the end of the agent's MIB was reached",
+		}
+	case gosnmp.AuthorizationError, gosnmp.NoAccess:
+		e.Kind, e.Summary = KindAuthorization, "the agent refused access"
+		e.Causes = []string{
+			"the community or user is not allowed this operation",
+			"the access view (VACM) excludes the object",
+		}
+	case gosnmp.NotWritable, gosnmp.ReadOnly:
+		e.Kind, e.Summary = KindNotWritable, "the object cannot be written"
+		e.Causes = []string{
+			"the object is read-only, in the MIB or in this agent's implementation",
+			"a read-only community was used for SET",
+		}
+	case gosnmp.BadValue, gosnmp.WrongType, gosnmp.WrongLength, gosnmp.WrongEncoding,
+		gosnmp.WrongValue, gosnmp.InconsistentValue, gosnmp.NoCreation, gosnmp.InconsistentName:
+		e.Kind, e.Summary = KindBadValue, "the agent rejected the value"
+		e.Causes = []string{
+			"the value's type does not match the object's SYNTAX",
+			"the value is outside the allowed range, size, or enumeration",
+			"creating a row needs other columns set in the same request",
+		}
+	default:
+		e.Kind, e.Summary = KindAgentFailure, "the agent failed to process the request"
+		e.Causes = []string{
+			"an agent or subagent bug, often in one object's implementation",
+			"retry the varbinds one at a time to isolate the failing object",
+		}
+	}
+	e.Summary += " (" + status + ")"
+	return e
+}
@@ -257,13 +257,31 @@ func FormatPDUToResult(pdu gosnmp.SnmpPDU, m *mib.Mib) Result {
 		}
 	}
 	return Result{
-		OID:      pdu.Name,
-		Name:     name,
-		Value:    formatPDU(pdu, node, m),
-		TypeName: pduTypeName(pdu.Type),
+		OID:       pdu.Name,
+		Name:      name,
+		Value:     formatPDU(pdu, node, m),
+		TypeName:  pduTypeName(pdu.Type),
+		PDU:       pdu,
+		Violation: ObjectViolation(pdu, instanceObject(node, oid)),
 	}
 }
 
+// RawValue renders a PDU value without MIB context: numbers in decimal,
+// octet strings as hex, OIDs and IP addresses in dotted form.
+func RawValue(pdu gosnmp.SnmpPDU) string {
+	switch pdu.Type {
+	case gosnmp.OctetString, gosnmp.Opaque:
+		if b, ok := pdu.Value.([]byte); ok {
+			return hex.EncodeToString(b)
+		}
+	case gosnmp.Counter64:
+		return gosnmp.ToBigInt(pdu.Value).String()
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null:
+		return formatPDU(pdu, nil, nil)
+	}
+	return fmt.Sprint(pdu.Value)
+}
+
 // ExtractNumeric extracts a numeric value from an SNMP PDU suitable for
 // delta/rate computation. Returns the value and true for numeric types
 // (Integer, Counter32, Counter64, Gauge32, Uinteger32, TimeTicks,
@@ -292,6 +310,27 @@ func ExtractNumeric(pdu gosnmp.SnmpPDU) (float64, bool) {
 	return 0, false
 }
 
+// IntegerValue extracts an integer-valued PDU as int64 for expression
+// evaluation. Unsigned values above math.MaxInt64 are clamped. Returns
+// 0, false for non-integer types.
+func IntegerValue(pdu gosnmp.SnmpPDU) (int64, bool) {
+	switch pdu.Type {
+	case gosnmp.Integer:
+		return toInt64(pdu.Value)
+	case gosnmp.Counter32, gosnmp.Gauge32, gosnmp.Uinteger32, gosnmp.TimeTicks:
+		if v, ok := toUint64(pdu.Value); ok {
+			return int64(v), true
+		}
+	case gosnmp.Counter64:
+		v := gosnmp.ToBigInt(pdu.Value)
+		if !v.IsInt64() {
+			return math.MaxInt64, true
+		}
+		return v.Int64(), true
+	}
+	return 0, false
+}
+
 func toInt64(v any) (int64, bool) {
 	switch n := v.(type) {
 	case int:
@@ -0,0 +1,87 @@
+package snmp
+
+import (
+	"sync"
+	"time"
+)
+
+// Gentle mode settings, for agents whose CPU suffers under bulk walks.
+const (
+	gentleRate           = 5  // requests per second
+	gentleParallel       = 1  // connections used by parallel operations
+	gentleMaxRepetitions = 10 // GETBULK max-repetitions
+)
+
+// rateWindow is the period the displayed request rate is averaged over.
+const rateWindow = 5 * time.Second
+
+// limiter spaces out a session's requests to keep under a rate and
+// records when they were sent. A session and its forks share one, so the
+// limit holds across parallel operations. Retries count as requests.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration // minimum gap between requests, 0 for no limit
+	next     time.Time     // earliest time the next request may go out
+	sent     []time.Time   // send times within rateWindow, oldest first
+}
+
+func newLimiter(rate float64) *limiter {
+	l := &limiter{}
+	if rate > 0 {
+		l.interval = time.Duration(float64(time.Second) / rate)
+	}
+	return l
+}
+
+// wait blocks until a request may be sent and returns how long it waited.
+// Concurrent callers are given successive slots.
+func (l *limiter) wait() time.Duration {
+	l.mu.Lock()
+	now := time.Now()
+	var d time.Duration
+	if l.interval > 0 {
+		if l.next.After(now) {
+			d = l.next.Sub(now)
+		}
+		l.next = now.Add(d + l.interval)
+	}
+	l.sent = append(l.sent, now.Add(d))
+	l.trim(now)
+	l.mu.Unlock()
+
+	time.Sleep(d)
+	return d
+}
+
+// trim drops send times that have left the rate window.
+func (l *limiter) trim(now time.Time) {
+	cutoff := now.Add(-rateWindow)
+	i := 0
+	for i < len(l.sent) && l.sent[i].Before(cutoff) {
+		i++
+	}
+	l.sent = l.sent[i:]
+}
+
+// rate returns the requests per second sent over the last rateWindow.
+func (l *limiter) rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.trim(now)
+	n := 0
+	for _, t := range l.sent {
+		if !t.After(now) {
+			n++
+		}
+	}
+	return float64(n) / rateWindow.Seconds()
+}
+
+// limit returns the configured rate, 0 when unlimited.
+func (l *limiter) limit() float64 {
+	if l.interval == 0 {
+		return 0
+	}
+	return float64(time.Second) / float64(l.interval)
+}
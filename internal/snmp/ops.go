@@ -4,19 +4,22 @@ import (
 	"context"
 	"errors"
 	"slices"
+	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/golangsnmp/gomib/mib"
 	"github.com/gosnmp/gosnmp"
 )
 
-// doWalk dispatches to Walk or BulkWalk based on the SNMP version.
-// SNMPv1 does not support BulkWalk.
-func doWalk(client *gosnmp.GoSNMP, oid string, fn gosnmp.WalkFunc) error {
-	if client.Version == gosnmp.Version1 {
-		return client.Walk(oid, fn)
-	}
-	return client.BulkWalk(oid, fn)
+// doWalk walks the subtree under oid with GETBULK, or GETNEXT for
+// SNMPv1, and classifies any failure.
+func doWalk(sess *Session, oid string, fn gosnmp.WalkFunc) error {
+	return resumeWalk(sess, oid, "", fn)
+}
+
+// resumeWalk is doWalk starting after the instance from.
+func resumeWalk(sess *Session, oid, from string, fn gosnmp.WalkFunc) error {
+	return sess.classify(sess.walkSubtree("walk "+oid, oid, from, fn))
 }
 
 // GetMsg carries the result of an SNMP GET operation.
@@ -39,30 +42,45 @@ type snmpOpFunc func(client *gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error)
 type snmpResultFunc func(results []gosnmp.SnmpPDU, err error) tea.Msg
 
 // snmpCmd runs an SNMP operation, checking that the session is connected
-// and wrapping the result using the provided buildMsg function.
+// and wrapping the result using the provided buildMsg function. Failures,
+// including a response with error-status set, are classified into *Error.
+// The name labels the operation's requests in the session's wire log.
 func snmpCmd(
 	sess *Session,
+	name string,
 	op snmpOpFunc,
 	buildMsg snmpResultFunc,
 ) tea.Cmd {
 	cmd := func() tea.Msg {
-		if !sess.IsConnected() {
-			return buildMsg(nil, errors.New("not connected"))
-		}
-
-		pkt, err := op(sess.client)
+		pkt, err := exchange(sess, name, op)
 		if err != nil {
 			return buildMsg(nil, err)
 		}
+		if err := packetError(pkt); err != nil {
+			return buildMsg(nil, err)
+		}
 		return buildMsg(pkt.Variables, nil)
 	}
 
 	return cmd
 }
 
+// exchange sends one request for snmpCmd and returns the raw response,
+// leaving error-status to the caller. Transport failures are classified.
+func exchange(sess *Session, name string, op snmpOpFunc) (*gosnmp.SnmpPacket, error) {
+	if !sess.IsConnected() {
+		return nil, errors.New("not connected")
+	}
+	pkt, err := sess.request(name, op)
+	if err != nil {
+		return nil, sess.classify(err)
+	}
+	return pkt, nil
+}
+
 // GetCmd performs an SNMP GET on the given OIDs.
 func GetCmd(sess *Session, oids []string) tea.Cmd {
-	return snmpCmd(sess,
+	return snmpCmd(sess, "get",
 		func(client *gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error) {
 			return client.Get(oids)
 		},
@@ -74,7 +92,7 @@ func GetCmd(sess *Session, oids []string) tea.Cmd {
 
 // GetNextCmd performs an SNMP GetNext on the given OID.
 func GetNextCmd(sess *Session, oid string) tea.Cmd {
-	return snmpCmd(sess,
+	return snmpCmd(sess, "getnext "+oid,
 		func(client *gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error) {
 			return client.GetNext([]string{oid})
 		},
@@ -84,6 +102,34 @@ func GetNextCmd(sess *Session, oid string) tea.Cmd {
 	)
 }
 
+// IdentifyMsg carries the sysObjectID an agent reported right after
+// connecting.
+type IdentifyMsg struct {
+	Target   string
+	ObjectID string // dotted, "" if the agent did not return one
+	Err      error
+}
+
+// IdentifyCmd reads the agent's sysObjectID, which names the vendor and
+// product through their registration OIDs.
+func IdentifyCmd(sess *Session) tea.Cmd {
+	target := sess.Target
+	return snmpCmd(sess, "identify",
+		func(client *gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error) {
+			return client.Get([]string{sysObjectIDOID})
+		},
+		func(results []gosnmp.SnmpPDU, err error) tea.Msg {
+			msg := IdentifyMsg{Target: target, Err: err}
+			for _, pdu := range results {
+				if s, ok := pdu.Value.(string); ok && pdu.Type == gosnmp.ObjectIdentifier {
+					msg.ObjectID = strings.TrimPrefix(s, ".")
+				}
+			}
+			return msg
+		},
+	)
+}
+
 // WalkSession tracks an in-progress SNMP walk.
 type WalkSession struct {
 	Ch     <-chan walkBatch
@@ -108,8 +154,9 @@ const walkBatchSize = 100
 
 // StartWalkCmd begins an SNMP walk and returns the walk session and a command
 // that yields the first batch. The walk goroutine sends PDU batches to a channel;
-// each handled batch must re-issue WaitWalkCmd until done.
-func StartWalkCmd(sess *Session, rootOID string) (*WalkSession, tea.Cmd) {
+// each handled batch must re-issue WaitWalkCmd until done. A non-empty from
+// resumes an interrupted walk after that instance.
+func StartWalkCmd(sess *Session, rootOID, from string) (*WalkSession, tea.Cmd) {
 	ch := make(chan walkBatch, 8)
 	ctx, cancel := context.WithCancel(context.Background())
 	ws := &WalkSession{Ch: ch, Cancel: cancel}
@@ -134,7 +181,7 @@ func StartWalkCmd(sess *Session, rootOID string) (*WalkSession, tea.Cmd) {
 			return nil
 		}
 
-		err := doWalk(sess.client, rootOID, walkFn)
+		err := resumeWalk(sess, rootOID, from, walkFn)
 
 		// Flush remaining results
 		if len(batch) > 0 {
@@ -170,13 +217,12 @@ func WaitWalkCmd(ch <-chan walkBatch) tea.Cmd {
 	}
 }
 
-// TableDataMsg carries the result of a table data fetch.
-type TableDataMsg struct {
-	TableName string
-	Columns   []string   // column names
-	Rows      [][]string // rows[r][c] = formatted value
-	IndexCols int        // number of leading index columns
-	Err       error
+// TableRow holds one row of table data, keyed by its instance suffix.
+type TableRow struct {
+	Suffix     string           // index portion of the instance OID
+	Cells      []string         // formatted value per column
+	PDUs       []gosnmp.SnmpPDU // raw varbind per column, zero value if absent
+	Violations []string         // out-of-spec description per column, "" if conforming
 }
 
 // tableColInfo describes a single column in a table walk.
@@ -186,12 +232,6 @@ type tableColInfo struct {
 	idx  int // position in output
 }
 
-// tableRowData holds the cell values for one row, keyed by index suffix.
-type tableRowData struct {
-	suffix string
-	cells  []string // one per column
-}
-
 // tableSchema holds the column layout and index count for a table.
 type tableSchema struct {
 	colMap    map[string]*tableColInfo // column OID -> info
@@ -247,115 +287,34 @@ func buildTableSchema(tbl *mib.Object) *tableSchema {
 	}
 }
 
-// tableWalkCollector accumulates PDU data during a table walk, organizing
-// values into rows keyed by index suffix.
-type tableWalkCollector struct {
-	schema   *tableSchema
-	m        *mib.Mib
-	rowMap   map[string]*tableRowData
-	rowOrder []string
-}
-
-// newTableWalkCollector creates a collector for the given schema and MIB.
-func newTableWalkCollector(schema *tableSchema, m *mib.Mib) *tableWalkCollector {
-	return &tableWalkCollector{
-		schema: schema,
-		m:      m,
-		rowMap: make(map[string]*tableRowData),
-	}
-}
-
-// handlePDU processes a single walk PDU, placing the formatted value into
-// the correct row and column. It returns nil for PDUs that cannot be mapped.
-func (c *tableWalkCollector) handlePDU(pdu gosnmp.SnmpPDU) error {
+// cell formats a walked instance for the table. It returns false for
+// instances that do not belong to one of the schema's columns.
+func (s *tableSchema) cell(pdu gosnmp.SnmpPDU, m *mib.Mib) (TableCell, bool) {
 	oid, err := mib.ParseOID(pdu.Name)
 	if err != nil {
-		return nil // skip unparseable
+		return TableCell{}, false
 	}
 
-	node := c.m.LongestPrefixByOID(oid)
+	node := m.LongestPrefixByOID(oid)
 	if node == nil {
-		return nil
+		return TableCell{}, false
 	}
 
-	colOID := node.OID().String()
-	ci, ok := c.schema.colMap[colOID]
+	ci, ok := s.colMap[node.OID().String()]
 	if !ok {
-		return nil // not one of our columns
-	}
-
-	suffix := oid[len(node.OID()):]
-	suffixStr := suffix.String()
-	if suffixStr == "" {
-		suffixStr = "0"
-	}
-
-	rd, exists := c.rowMap[suffixStr]
-	if !exists {
-		rd = &tableRowData{
-			suffix: suffixStr,
-			cells:  make([]string, len(c.schema.colList)),
-		}
-		c.rowMap[suffixStr] = rd
-		c.rowOrder = append(c.rowOrder, suffixStr)
+		return TableCell{}, false // not one of our columns
 	}
 
-	rd.cells[ci.idx] = formatPDU(pdu, node, c.m)
-	return nil
-}
-
-// buildTableRows converts collected walk data into ordered row slices,
-// filling empty cells with "-".
-func (c *tableWalkCollector) buildTableRows() [][]string {
-	if len(c.rowOrder) == 0 {
-		return nil
+	suffix := oid[len(node.OID()):].String()
+	if suffix == "" {
+		suffix = "0"
 	}
 
-	rows := make([][]string, 0, len(c.rowOrder))
-	for _, suffix := range c.rowOrder {
-		rd := c.rowMap[suffix]
-		for i := range rd.cells {
-			if rd.cells[i] == "" {
-				rd.cells[i] = "-"
-			}
-		}
-		rows = append(rows, rd.cells)
-	}
-	return rows
-}
-
-// TableWalkCmd walks a table OID and organizes the results into rows and columns.
-// It uses the MIB to determine column structure and index composition.
-func TableWalkCmd(sess *Session, tbl *mib.Object, m *mib.Mib) tea.Cmd {
-	return func() tea.Msg {
-		if !sess.IsConnected() {
-			return TableDataMsg{Err: errors.New("not connected")}
-		}
-
-		tableName := tbl.Name()
-		tableOID := tbl.OID().String()
-
-		cols := tbl.Columns()
-		if len(cols) == 0 {
-			return TableDataMsg{TableName: tableName, Err: errors.New("no columns defined")}
-		}
-
-		schema := buildTableSchema(tbl)
-		collector := newTableWalkCollector(schema, m)
-
-		walkFn := func(pdu gosnmp.SnmpPDU) error {
-			return collector.handlePDU(pdu)
-		}
-
-		if err := doWalk(sess.client, tableOID, walkFn); err != nil {
-			return TableDataMsg{TableName: tableName, Err: err}
-		}
-
-		return TableDataMsg{
-			TableName: tableName,
-			Columns:   schema.colNames,
-			Rows:      collector.buildTableRows(),
-			IndexCols: schema.indexCols,
-		}
-	}
+	return TableCell{
+		Suffix:    suffix,
+		Col:       ci.idx,
+		Text:      formatPDU(pdu, node, m),
+		PDU:       pdu,
+		Violation: ObjectViolation(pdu, instanceObject(node, oid)),
+	}, true
 }
@@ -2,6 +2,7 @@ package snmp
 
 import (
 	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
 )
 
 // OpKind identifies the type of SNMP operation that produced a result group.
@@ -11,14 +12,17 @@ const (
 	OpGet OpKind = iota
 	OpGetNext
 	OpWalk
+	OpDiscover
 )
 
 // Result is a single formatted SNMP result.
 type Result struct {
-	OID      string // dotted OID string
-	Name     string // resolved name (e.g. "sysDescr.0")
-	Value    string // formatted value
-	TypeName string // type label (e.g. "STRING", "INTEGER")
+	OID       string         // dotted OID string
+	Name      string         // resolved name (e.g. "sysDescr.0")
+	Value     string         // formatted value
+	TypeName  string         // type label (e.g. "STRING", "INTEGER")
+	PDU       gosnmp.SnmpPDU // raw varbind, for typed filtering
+	Violation string         // how the value breaks the object's syntax, "" if it conforms
 }
 
 // ResultGroup is a set of results from a single SNMP operation.
@@ -27,6 +31,7 @@ type ResultGroup struct {
 	Label       string // short description (e.g. "GET sysDescr.0")
 	Results     []Result
 	Err         error   // non-nil if the operation failed
+	Partial     bool    // a walk stopped early; Results holds what arrived and the walk can resume
 	WalkRootOID mib.OID // root OID for walk operations (used by tree view)
 }
 
@@ -0,0 +1,262 @@
+package snmp
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// Subnet sweep settings. Most addresses in a sweep never answer, so the
+// timeout is short and probes run in parallel.
+const (
+	scanWorkers     = 16 // addresses probed at once unless the profile says otherwise
+	scanMaxHostBits = 12 // largest sweep: 4096 addresses
+	scanTimeout     = time.Second
+	scanRetries     = 1
+)
+
+// System group instances read from each agent found by a sweep.
+const (
+	sysDescrOID    = "1.3.6.1.2.1.1.1.0"
+	sysObjectIDOID = "1.3.6.1.2.1.1.2.0"
+	sysNameOID     = "1.3.6.1.2.1.1.5.0"
+)
+
+// ScanHit is an address whose agent answered a sweep.
+type ScanHit struct {
+	Addr       netip.Addr
+	Port       uint16 // port the agent answered on
+	Descr      string // sysDescr
+	ObjectID   string // sysObjectID, dotted
+	ObjectName string // sysObjectID named through the MIB
+	Name       string // sysName
+	RTT        time.Duration
+}
+
+// Target returns the hit as a connection target, with the port only when
+// it is not the standard 161.
+func (h ScanHit) Target() string {
+	if h.Port == 0 || h.Port == 161 {
+		return h.Addr.String()
+	}
+	return netip.AddrPortFrom(h.Addr, h.Port).String()
+}
+
+// ScanSession tracks an in-progress subnet sweep.
+type ScanSession struct {
+	Prefix string
+	Total  int // addresses being probed
+	Ch     <-chan scanBatch
+	Cancel context.CancelFunc
+}
+
+// scanBatch carries agents found and addresses probed, or the end of the
+// sweep.
+type scanBatch struct {
+	hits   []ScanHit
+	probed int
+	done   bool
+}
+
+// ScanBatchMsg carries sweep progress to the update loop.
+type ScanBatchMsg struct {
+	Hits      []ScanHit
+	Probed    int // addresses probed since the previous message
+	Done      bool
+	Cancelled bool
+}
+
+// ScanHosts expands a CIDR prefix, or a single address, into the addresses
+// a sweep probes. The network and broadcast addresses of IPv4 prefixes
+// shorter than /31 are left out.
+func ScanHosts(cidr string) ([]netip.Addr, error) {
+	prefix, err := parseScanPrefix(cidr)
+	if err != nil {
+		return nil, err
+	}
+	return prefixHosts(prefix), nil
+}
+
+// prefixHosts lists the addresses of a prefix that a sweep probes.
+func prefixHosts(prefix netip.Prefix) []netip.Addr {
+	var hosts []netip.Addr
+	for a := prefix.Addr(); a.IsValid() && prefix.Contains(a); a = a.Next() {
+		hosts = append(hosts, a)
+	}
+	if prefix.Addr().Is4() && prefix.Bits() < 31 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts
+}
+
+// parseScanPrefix parses a sweep target, treating a bare address as a
+// single-host prefix, and checks the sweep size.
+func parseScanPrefix(cidr string) (netip.Prefix, error) {
+	cidr = strings.TrimSpace(cidr)
+	var prefix netip.Prefix
+	if strings.Contains(cidr, "/") {
+		p, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("not a CIDR prefix: %s", cidr)
+		}
+		prefix = p.Masked()
+	} else {
+		a, err := netip.ParseAddr(cidr)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("not an address or CIDR prefix: %s", cidr)
+		}
+		prefix = netip.PrefixFrom(a, a.BitLen())
+	}
+	if prefix.Addr().BitLen()-prefix.Bits() > scanMaxHostBits {
+		return netip.Prefix{}, fmt.Errorf("%s has more than %d addresses", prefix, 1<<scanMaxHostBits)
+	}
+	return prefix, nil
+}
+
+// StartScanCmd sweeps a prefix with a GET of sysDescr, sysObjectID and
+// sysName using the profile's version and credentials. The port comes from
+// the prefix ("10.1.2.0/24:1161") or else the profile's target, and is 161
+// unless one of them names it. The profile's rate
+// limit applies across the whole sweep and its parallelism bounds how many
+// addresses are probed at once. Each handled batch must re-issue
+// WaitScanCmd until done.
+func StartScanCmd(p Profile, cidr string, m *mib.Mib) (*ScanSession, tea.Cmd, error) {
+	host, port := parseTarget(cidr)
+	if host == cidr {
+		_, port = parseTarget(p.Target)
+	}
+	prefix, err := parseScanPrefix(host)
+	if err != nil {
+		return nil, nil, err
+	}
+	hosts := prefixHosts(prefix)
+	if _, err := ParseVersion(p.Version); err != nil {
+		return nil, nil, err
+	}
+
+	rate, _ := p.limits()
+	workers := p.MaxParallel
+	if workers <= 0 {
+		workers = scanWorkers
+	}
+	if p.Gentle {
+		workers = gentleParallel
+	}
+	limit := newLimiter(rate)
+
+	ch := make(chan scanBatch, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	ss := &ScanSession{Prefix: prefix.String(), Total: len(hosts), Ch: ch, Cancel: cancel}
+
+	go func() {
+		defer close(ch)
+		jobs := make(chan netip.Addr)
+		var wg sync.WaitGroup
+		for range min(workers, len(hosts)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for addr := range jobs {
+					b := scanBatch{probed: 1}
+					if hit, ok := probeHost(p, addr, port, limit, m); ok {
+						b.hits = []ScanHit{hit}
+					}
+					select {
+					case ch <- b:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+
+	feed:
+		for _, addr := range hosts {
+			select {
+			case jobs <- addr:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(jobs)
+		wg.Wait()
+
+		select {
+		case ch <- scanBatch{done: true}:
+		case <-ctx.Done():
+		}
+	}()
+
+	return ss, WaitScanCmd(ch), nil
+}
+
+// probeHost asks one address for its system identity. Any response,
+// even an error-status, means an agent is there.
+func probeHost(p Profile, addr netip.Addr, port uint16, limit *limiter, m *mib.Mib) (ScanHit, bool) {
+	client, err := newClient(p, addr.String(), port)
+	if err != nil {
+		return ScanHit{}, false
+	}
+	client.Timeout = scanTimeout
+	client.Retries = scanRetries
+	if err := client.Connect(); err != nil {
+		return ScanHit{}, false
+	}
+	client.Conn = &wireConn{Conn: client.Conn, limit: limit}
+	defer client.Conn.Close()
+
+	start := time.Now()
+	pkt, err := client.Get([]string{sysDescrOID, sysObjectIDOID, sysNameOID})
+	if err != nil {
+		return ScanHit{}, false
+	}
+	hit := ScanHit{Addr: addr, Port: port, RTT: time.Since(start)}
+	for _, pdu := range pkt.Variables {
+		if !HasValue(pdu) {
+			continue
+		}
+		switch strings.TrimPrefix(pdu.Name, ".") {
+		case sysDescrOID:
+			hit.Descr = formatOctetString(pdu.Value, nil)
+		case sysObjectIDOID:
+			hit.ObjectID = strings.TrimPrefix(formatOID(pdu.Value, nil), ".")
+			hit.ObjectName = formatOID(pdu.Value, m)
+		case sysNameOID:
+			hit.Name = formatOctetString(pdu.Value, nil)
+		}
+	}
+	return hit, true
+}
+
+// WaitScanCmd returns a command that blocks until sweep progress is ready,
+// collecting any further batches already queued.
+func WaitScanCmd(ch <-chan scanBatch) tea.Cmd {
+	return func() tea.Msg {
+		var msg ScanBatchMsg
+		b, ok := <-ch
+		for {
+			if !ok {
+				// Closed without a final batch: the sweep was cancelled.
+				msg.Done, msg.Cancelled = true, true
+				return msg
+			}
+			msg.Hits = append(msg.Hits, b.hits...)
+			msg.Probed += b.probed
+			if b.done {
+				msg.Done = true
+				return msg
+			}
+			select {
+			case b, ok = <-ch:
+			default:
+				return msg
+			}
+		}
+	}
+}
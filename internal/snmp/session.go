@@ -5,6 +5,7 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/gosnmp/gosnmp"
@@ -12,12 +13,29 @@ import (
 
 // Session holds SNMP connection state.
 type Session struct {
+	mu        sync.Mutex // held for each request; see request
 	client    *gosnmp.GoSNMP
 	Target    string
 	Version   string
+	Wire      *WireLog // recent raw exchanges, for the packet inspector
+	Gentle    bool     // gentle mode: low rate, no parallelism, small bulks
+	oidOrder  string   // OIDOrderStrict or OIDOrderLenient
+	limit     *limiter // request pacing, shared with forks
+	parallel  int      // connections parallel operations may use
 	connected bool
 }
 
+// Rate returns the requests per second recently sent to the agent,
+// including retries and requests from parallel connections.
+func (s *Session) Rate() float64 {
+	return s.limit.rate()
+}
+
+// RateLimit returns the session's request rate limit, 0 when unlimited.
+func (s *Session) RateLimit() float64 {
+	return s.limit.limit()
+}
+
 // IsConnected reports whether the session is usable for SNMP operations.
 // It is safe to call on a nil receiver.
 func (s *Session) IsConnected() bool {
@@ -36,6 +54,55 @@ func (s *Session) Close() {
 	s.connected = false
 }
 
+// request sends one request on the client, labelled op in the wire log.
+// A gosnmp client handles one request at a time, so every operation on
+// the session goes through here and waits its turn; walks take the lock
+// per request, letting other operations in between pages.
+func (s *Session) request(op string, fn func(*gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error)) (*gosnmp.SnmpPacket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Wire.setOp(op)
+	return fn(s.client)
+}
+
+// fork opens a second connection to the session's agent with the same
+// version and credentials, for operations that run requests in parallel.
+// A gosnmp client handles one request at a time. The fork records into
+// the session's wire log; close it when done.
+func (s *Session) fork() (*Session, error) {
+	c := s.client
+	client := &gosnmp.GoSNMP{
+		Target:         c.Target,
+		Port:           c.Port,
+		Transport:      c.Transport,
+		Version:        c.Version,
+		Community:      c.Community,
+		Timeout:        c.Timeout,
+		Retries:        c.Retries,
+		MaxRepetitions: c.MaxRepetitions,
+		SecurityModel:  c.SecurityModel,
+		MsgFlags:       c.MsgFlags,
+	}
+	if c.SecurityParameters != nil {
+		client.SecurityParameters = c.SecurityParameters.Copy()
+	}
+	if err := client.Connect(); err != nil {
+		return nil, err
+	}
+	client.Conn = &wireConn{Conn: client.Conn, log: s.Wire, limit: s.limit}
+	return &Session{
+		client:    client,
+		Target:    s.Target,
+		Version:   s.Version,
+		Wire:      s.Wire,
+		Gentle:    s.Gentle,
+		oidOrder:  s.oidOrder,
+		limit:     s.limit,
+		parallel:  s.parallel,
+		connected: true,
+	}, nil
+}
+
 // ConnectMsg is sent when a connection attempt completes.
 type ConnectMsg struct {
 	Session *Session
@@ -58,6 +125,33 @@ type Profile struct {
 	AuthPass      string `json:"auth_pass,omitempty"`
 	PrivProto     string `json:"priv_proto,omitempty"` // "DES", "AES", "AES192", "AES256"
 	PrivPass      string `json:"priv_pass,omitempty"`
+
+	// OIDOrder is the walk policy for agents returning OIDs out of order:
+	// OIDOrderStrict (the default) or OIDOrderLenient.
+	OIDOrder string `json:"oid_order,omitempty"`
+
+	// Load limits. Gentle mode caps the rate at a few requests per second,
+	// disables parallel fetches and shrinks GETBULK responses, for devices
+	// that cannot take a bulk walk.
+	RateLimit   float64 `json:"rate_limit,omitempty"`   // requests per second, 0 for no limit
+	MaxParallel int     `json:"max_parallel,omitempty"` // connections for parallel fetches, 0 for the default
+	Gentle      bool    `json:"gentle,omitempty"`
+}
+
+// limits returns the profile's effective rate limit and parallelism, with
+// gentle mode tightening whatever is set.
+func (p Profile) limits() (rate float64, parallel int) {
+	rate, parallel = p.RateLimit, p.MaxParallel
+	if parallel <= 0 {
+		parallel = tableFetchWorkers
+	}
+	if p.Gentle {
+		if rate <= 0 || rate > gentleRate {
+			rate = gentleRate
+		}
+		parallel = gentleParallel
+	}
+	return rate, parallel
 }
 
 // ParseVersion converts a version string to the gosnmp version constant.
@@ -133,46 +227,68 @@ func parsePrivProto(s string) gosnmp.SnmpV3PrivProtocol {
 	}
 }
 
+// newClient builds an unconnected client for host and port with the
+// profile's version and credentials.
+func newClient(p Profile, host string, port uint16) (*gosnmp.GoSNMP, error) {
+	ver, err := ParseVersion(p.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &gosnmp.GoSNMP{
+		Target:  host,
+		Port:    port,
+		Version: ver,
+		Timeout: gosnmp.Default.Timeout,
+		Retries: gosnmp.Default.Retries,
+	}
+
+	if ver == gosnmp.Version3 {
+		client.SecurityModel = gosnmp.UserSecurityModel
+		client.MsgFlags = parseSecurityLevel(p.SecurityLevel)
+		client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+			UserName:                 p.Username,
+			AuthenticationProtocol:   parseAuthProto(p.AuthProto),
+			AuthenticationPassphrase: p.AuthPass,
+			PrivacyProtocol:          parsePrivProto(p.PrivProto),
+			PrivacyPassphrase:        p.PrivPass,
+		}
+	} else {
+		client.Community = p.Community
+	}
+	if p.Gentle {
+		client.MaxRepetitions = gentleMaxRepetitions
+	}
+	return client, nil
+}
+
 // ConnectCmd returns a tea.Cmd that connects to an SNMP device.
 func ConnectCmd(p Profile) tea.Cmd {
 	return func() tea.Msg {
-		ver, err := ParseVersion(p.Version)
+		host, port := parseTarget(p.Target)
+		client, err := newClient(p, host, port)
 		if err != nil {
 			return ConnectMsg{Err: err}
 		}
 
-		host, port := parseTarget(p.Target)
-
-		client := &gosnmp.GoSNMP{
-			Target:  host,
-			Port:    port,
-			Version: ver,
-			Timeout: gosnmp.Default.Timeout,
-			Retries: gosnmp.Default.Retries,
-		}
-
-		if ver == gosnmp.Version3 {
-			client.SecurityModel = gosnmp.UserSecurityModel
-			client.MsgFlags = parseSecurityLevel(p.SecurityLevel)
-			client.SecurityParameters = &gosnmp.UsmSecurityParameters{
-				UserName:                 p.Username,
-				AuthenticationProtocol:   parseAuthProto(p.AuthProto),
-				AuthenticationPassphrase: p.AuthPass,
-				PrivacyProtocol:          parsePrivProto(p.PrivProto),
-				PrivacyPassphrase:        p.PrivPass,
-			}
-		} else {
-			client.Community = p.Community
-		}
-
 		if err := client.Connect(); err != nil {
 			return ConnectMsg{Err: err}
 		}
 
+		rate, parallel := p.limits()
+		wire := &WireLog{}
+		limit := newLimiter(rate)
+		client.Conn = &wireConn{Conn: client.Conn, log: wire, limit: limit}
+
 		sess := &Session{
 			client:    client,
 			Target:    p.Target,
 			Version:   p.Version,
+			Wire:      wire,
+			Gentle:    p.Gentle,
+			oidOrder:  p.OIDOrder,
+			limit:     limit,
+			parallel:  parallel,
 			connected: true,
 		}
 		return ConnectMsg{Session: sess}
@@ -0,0 +1,463 @@
+package snmp
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
+)
+
+// RowStatus values (SNMPv2-TC).
+const (
+	RowStatusActive        = 1
+	RowStatusNotInService  = 2
+	RowStatusNotReady      = 3
+	RowStatusCreateAndGo   = 4
+	RowStatusCreateAndWait = 5
+	RowStatusDestroy       = 6
+)
+
+// SetMsg carries the result of an SNMP SET operation.
+type SetMsg struct {
+	Label   string
+	Results []gosnmp.SnmpPDU
+	Err     error
+}
+
+// SetCmd performs an SNMP SET with the given varbinds. All varbinds are sent
+// in a single PDU so the agent applies them atomically.
+func SetCmd(sess *Session, label string, pdus []gosnmp.SnmpPDU) tea.Cmd {
+	return snmpCmd(sess, "set "+label,
+		func(client *gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error) {
+			return client.Set(pdus)
+		},
+		func(results []gosnmp.SnmpPDU, err error) tea.Msg {
+			return SetMsg{Label: label, Results: results, Err: err}
+		},
+	)
+}
+
+// IsRowStatus reports whether the object's type is RowStatus or a
+// refinement of it.
+func IsRowStatus(obj *mib.Object) bool {
+	if obj == nil {
+		return false
+	}
+	for t := obj.Type(); t != nil; t = t.Parent() {
+		if t.Name() == "RowStatus" {
+			return true
+		}
+	}
+	return false
+}
+
+// RowStatusColumn returns the RowStatus column of a table, or nil if the
+// table does not support row creation via RowStatus.
+func RowStatusColumn(tbl *mib.Object) *mib.Object {
+	for _, col := range tbl.Columns() {
+		if IsRowStatus(col) {
+			return col
+		}
+	}
+	return nil
+}
+
+// IsWritable reports whether the object's MAX-ACCESS permits SET.
+func IsWritable(obj *mib.Object) bool {
+	switch obj.Access() {
+	case mib.AccessReadWrite, mib.AccessReadCreate, mib.AccessWriteOnly:
+		return true
+	}
+	return false
+}
+
+// baseOf returns the effective base type of an object, or BaseUnknown.
+func baseOf(obj *mib.Object) mib.BaseType {
+	if t := obj.Type(); t != nil {
+		return t.EffectiveBase()
+	}
+	return mib.BaseUnknown
+}
+
+// TypeHint returns a short description of the values accepted for an
+// object, suitable as an input placeholder.
+func TypeHint(obj *mib.Object) string {
+	base := baseOf(obj)
+	hint := base.String()
+	if t := obj.Type(); t != nil && t.Name() != "" {
+		hint = t.Name()
+	}
+	switch {
+	case len(obj.EffectiveBits()) > 0:
+		return hint + " (bit, bit, ...)"
+	case len(obj.EffectiveRanges()) > 0:
+		return hint + " " + formatRanges(obj.EffectiveRanges())
+	case len(obj.EffectiveSizes()) > 0:
+		return hint + " SIZE " + formatRanges(obj.EffectiveSizes())
+	}
+	return hint
+}
+
+func formatRanges(ranges []mib.Range) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		if r.Min == r.Max {
+			parts[i] = strconv.FormatInt(r.Min, 10)
+		} else {
+			parts[i] = fmt.Sprintf("%d..%d", r.Min, r.Max)
+		}
+	}
+	return "(" + strings.Join(parts, " | ") + ")"
+}
+
+// EncodeValue converts user-entered text into a SET varbind for the given
+// object instance. Enumerations accept a label, a number, or the
+// "label(n)" display form. Octet strings accept plain text or 0x-prefixed
+// hex. BITS accept a comma-separated list of bit labels.
+func EncodeValue(obj *mib.Object, instanceOID string, text string, m *mib.Mib) (gosnmp.SnmpPDU, error) {
+	pdu := gosnmp.SnmpPDU{Name: instanceOID}
+	text = strings.TrimSpace(text)
+
+	if bits := obj.EffectiveBits(); len(bits) > 0 {
+		b, err := encodeBits(text, bits)
+		if err != nil {
+			return pdu, err
+		}
+		pdu.Type = gosnmp.OctetString
+		pdu.Value = b
+		return pdu, nil
+	}
+
+	switch baseOf(obj) {
+	case mib.BaseInteger32:
+		v, err := parseInteger(obj, text)
+		if err != nil {
+			return pdu, err
+		}
+		if err := checkRanges(v, obj.EffectiveRanges()); err != nil {
+			return pdu, err
+		}
+		pdu.Type = gosnmp.Integer
+		pdu.Value = int(v)
+	case mib.BaseUnsigned32, mib.BaseGauge32, mib.BaseCounter32, mib.BaseTimeTicks:
+		v, err := strconv.ParseUint(text, 10, 32)
+		if err != nil {
+			return pdu, fmt.Errorf("invalid unsigned value %q", text)
+		}
+		if err := checkRanges(int64(v), obj.EffectiveRanges()); err != nil {
+			return pdu, err
+		}
+		pdu.Type = unsignedASN1(baseOf(obj))
+		pdu.Value = uint32(v)
+	case mib.BaseCounter64:
+		v, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return pdu, fmt.Errorf("invalid unsigned value %q", text)
+		}
+		pdu.Type = gosnmp.Counter64
+		pdu.Value = v
+	case mib.BaseIpAddress:
+		ip := net.ParseIP(text).To4()
+		if ip == nil {
+			return pdu, fmt.Errorf("invalid IPv4 address %q", text)
+		}
+		pdu.Type = gosnmp.IPAddress
+		pdu.Value = ip.String()
+	case mib.BaseObjectIdentifier:
+		oid, err := parseOIDValue(text, m)
+		if err != nil {
+			return pdu, err
+		}
+		pdu.Type = gosnmp.ObjectIdentifier
+		pdu.Value = "." + oid.String()
+	case mib.BaseOctetString, mib.BaseOpaque:
+		b := parseOctets(text)
+		if err := checkRanges(int64(len(b)), obj.EffectiveSizes()); err != nil {
+			return pdu, fmt.Errorf("length %d: %w", len(b), err)
+		}
+		pdu.Type = gosnmp.OctetString
+		if baseOf(obj) == mib.BaseOpaque {
+			pdu.Type = gosnmp.Opaque
+		}
+		pdu.Value = b
+	default:
+		return pdu, fmt.Errorf("unsupported type %s", baseOf(obj))
+	}
+	return pdu, nil
+}
+
+func unsignedASN1(base mib.BaseType) gosnmp.Asn1BER {
+	switch base {
+	case mib.BaseGauge32:
+		return gosnmp.Gauge32
+	case mib.BaseCounter32:
+		return gosnmp.Counter32
+	case mib.BaseTimeTicks:
+		return gosnmp.TimeTicks
+	}
+	return gosnmp.Uinteger32
+}
+
+// parseInteger parses a signed integer, resolving enum labels against the object.
+func parseInteger(obj *mib.Object, text string) (int64, error) {
+	if v, err := strconv.ParseInt(text, 10, 32); err == nil {
+		return v, nil
+	}
+	label := text
+	if i := strings.IndexByte(text, '('); i > 0 && strings.HasSuffix(text, ")") {
+		label = text[:i]
+	}
+	if nv, ok := obj.Enum(label); ok {
+		return nv.Value, nil
+	}
+	if len(obj.EffectiveEnums()) > 0 {
+		return 0, fmt.Errorf("unknown enum label %q", label)
+	}
+	return 0, fmt.Errorf("invalid integer %q", text)
+}
+
+// checkRanges reports an error if v lies outside every range. An empty
+// range list places no constraint.
+func checkRanges(v int64, ranges []mib.Range) error {
+	if len(ranges) == 0 {
+		return nil
+	}
+	for _, r := range ranges {
+		if v >= r.Min && v <= r.Max {
+			return nil
+		}
+	}
+	return fmt.Errorf("out of range %s", formatRanges(ranges))
+}
+
+// parseOctets decodes 0x-prefixed hex, falling back to the literal text.
+func parseOctets(text string) []byte {
+	if h, ok := strings.CutPrefix(text, "0x"); ok {
+		if b, err := hex.DecodeString(h); err == nil {
+			return b
+		}
+	}
+	return []byte(text)
+}
+
+// parseOIDValue parses a numeric OID or a MIB name with optional numeric suffix.
+func parseOIDValue(text string, m *mib.Mib) (mib.OID, error) {
+	if oid, err := mib.ParseOID(text); err == nil {
+		return oid, nil
+	}
+	name, rest, _ := strings.Cut(text, ".")
+	if m != nil {
+		if node := m.Node(name); node != nil && node.OID() != nil {
+			oid := node.OID()
+			if rest == "" {
+				return oid, nil
+			}
+			suffix, err := mib.ParseOID(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid OID suffix %q", rest)
+			}
+			return append(append(mib.OID{}, oid...), suffix...), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid OID %q", text)
+}
+
+// encodeBits converts a comma-separated list of bit labels into BITS octets.
+func encodeBits(text string, bits []mib.NamedValue) ([]byte, error) {
+	var maxBit int64
+	for _, nv := range bits {
+		maxBit = max(maxBit, nv.Value)
+	}
+	out := make([]byte, maxBit/8+1)
+	if text == "" || text == "(none)" {
+		return out, nil
+	}
+	for part := range strings.SplitSeq(text, ",") {
+		label := strings.TrimSpace(part)
+		if label == "" {
+			continue
+		}
+		found := false
+		for _, nv := range bits {
+			if nv.Label == label {
+				out[nv.Value/8] |= 1 << (7 - nv.Value%8)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown bit %q", label)
+		}
+	}
+	return out, nil
+}
+
+// EncodeIndex builds the instance suffix for a table row from one text
+// value per INDEX component, following the RFC 2578 s7.7 encoding rules.
+func EncodeIndex(indexes []mib.IndexEntry, values []string, m *mib.Mib) (mib.OID, error) {
+	if len(values) != len(indexes) {
+		return nil, fmt.Errorf("expected %d index values, got %d", len(indexes), len(values))
+	}
+	var suffix mib.OID
+	for i, idx := range indexes {
+		if idx.Object == nil {
+			return nil, fmt.Errorf("index %s: bare type indexes not supported", idx.TypeName)
+		}
+		name := idx.Object.Name()
+		text := strings.TrimSpace(values[i])
+		if text == "" {
+			return nil, fmt.Errorf("index %s is required", name)
+		}
+		arcs, err := encodeIndexComponent(idx, text, m)
+		if err != nil {
+			return nil, fmt.Errorf("index %s: %w", name, err)
+		}
+		suffix = append(suffix, arcs...)
+	}
+	return suffix, nil
+}
+
+func encodeIndexComponent(idx mib.IndexEntry, text string, m *mib.Mib) (mib.OID, error) {
+	obj := idx.Object
+	switch idx.Encoding {
+	case mib.IndexEncodingInteger:
+		v, err := parseInteger(obj, text)
+		if err != nil {
+			if u, uerr := strconv.ParseUint(text, 10, 32); uerr == nil {
+				v, err = int64(u), nil
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 {
+			return nil, errors.New("negative index value")
+		}
+		return mib.OID{uint32(v)}, nil
+	case mib.IndexEncodingIpAddress:
+		ip := net.ParseIP(text).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q", text)
+		}
+		return mib.OID{uint32(ip[0]), uint32(ip[1]), uint32(ip[2]), uint32(ip[3])}, nil
+	}
+
+	var arcs mib.OID
+	if baseOf(obj) == mib.BaseObjectIdentifier {
+		oid, err := parseOIDValue(text, m)
+		if err != nil {
+			return nil, err
+		}
+		arcs = oid
+	} else {
+		for _, c := range parseOctets(text) {
+			arcs = append(arcs, uint32(c))
+		}
+	}
+
+	switch idx.Encoding {
+	case mib.IndexEncodingFixedString, mib.IndexEncodingImplied:
+		if idx.Encoding == mib.IndexEncodingFixedString {
+			if err := checkRanges(int64(len(arcs)), obj.EffectiveSizes()); err != nil {
+				return nil, fmt.Errorf("length %d: %w", len(arcs), err)
+			}
+		}
+		return arcs, nil
+	default:
+		return append(mib.OID{uint32(len(arcs))}, arcs...), nil
+	}
+}
+
+// DecodeIndex splits an instance suffix into the arcs of each INDEX
+// component, undoing EncodeIndex: length prefixes are dropped and an
+// IMPLIED component takes the rest of the suffix.
+func DecodeIndex(indexes []mib.IndexEntry, suffix mib.OID) ([]mib.OID, error) {
+	comps := make([]mib.OID, 0, len(indexes))
+	for _, idx := range indexes {
+		if idx.Object == nil {
+			return nil, fmt.Errorf("index %s: bare type indexes not supported", idx.TypeName)
+		}
+		name := idx.Object.Name()
+		var n int
+		switch idx.Encoding {
+		case mib.IndexEncodingInteger:
+			n = 1
+		case mib.IndexEncodingIpAddress:
+			n = 4
+		case mib.IndexEncodingFixedString:
+			sizes := idx.Object.EffectiveSizes()
+			if len(sizes) != 1 {
+				return nil, fmt.Errorf("index %s: no fixed size", name)
+			}
+			n = int(sizes[0].Min)
+		case mib.IndexEncodingImplied:
+			n = len(suffix)
+		case mib.IndexEncodingLengthPrefixed:
+			if len(suffix) == 0 {
+				return nil, fmt.Errorf("index %s: missing length", name)
+			}
+			n = int(suffix[0])
+			suffix = suffix[1:]
+		default:
+			return nil, fmt.Errorf("index %s: unknown encoding", name)
+		}
+		if n > len(suffix) {
+			return nil, fmt.Errorf("index %s: suffix too short", name)
+		}
+		comps = append(comps, suffix[:n])
+		suffix = suffix[n:]
+	}
+	if len(suffix) > 0 {
+		return nil, fmt.Errorf("%d arcs left after the index", len(suffix))
+	}
+	return comps, nil
+}
+
+// EditValue renders a PDU value as text that EncodeValue accepts, so current
+// values can be presented for editing and round-trip unchanged.
+func EditValue(pdu gosnmp.SnmpPDU, obj *mib.Object, m *mib.Mib) string {
+	switch pdu.Type {
+	case gosnmp.Integer:
+		v, ok := toInt64(pdu.Value)
+		if !ok {
+			return fmt.Sprint(pdu.Value)
+		}
+		for _, nv := range obj.EffectiveEnums() {
+			if nv.Value == v {
+				return nv.Label
+			}
+		}
+		return strconv.FormatInt(v, 10)
+	case gosnmp.OctetString, gosnmp.Opaque:
+		b, ok := pdu.Value.([]byte)
+		if !ok {
+			return fmt.Sprint(pdu.Value)
+		}
+		if bits := obj.EffectiveBits(); len(bits) > 0 {
+			return formatBits(b, bits)
+		}
+		if len(b) == 0 {
+			return ""
+		}
+		if utf8.Valid(b) && isPrintable(b) && !strings.HasPrefix(string(b), "0x") {
+			return string(b)
+		}
+		return "0x" + hex.EncodeToString(b)
+	case gosnmp.ObjectIdentifier:
+		return formatOID(pdu.Value, m)
+	case gosnmp.Counter64:
+		return strconv.FormatUint(gosnmp.ToBigInt(pdu.Value).Uint64(), 10)
+	case gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Uinteger32:
+		if v, ok := toUint64(pdu.Value); ok {
+			return strconv.FormatUint(v, 10)
+		}
+	}
+	return fmt.Sprint(pdu.Value)
+}
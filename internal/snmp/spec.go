@@ -0,0 +1,167 @@
+package snmp
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
+)
+
+// Constraints is the value space a varbind is checked against: an object's
+// declared syntax, or a compliance or capability refinement of it.
+type Constraints struct {
+	Ranges []mib.Range
+	Sizes  []mib.Range
+	Enums  []mib.NamedValue
+	Bits   []mib.NamedValue
+}
+
+// ObjectConstraints returns the effective constraints of an object's syntax.
+func ObjectConstraints(obj *mib.Object) Constraints {
+	return Constraints{
+		Ranges: obj.EffectiveRanges(),
+		Sizes:  obj.EffectiveSizes(),
+		Enums:  obj.EffectiveEnums(),
+		Bits:   obj.EffectiveBits(),
+	}
+}
+
+// Refine narrows c by a SYNTAX refinement. Each list the refinement
+// specifies replaces the corresponding list in c; a nil refinement leaves
+// c unchanged.
+func (c Constraints) Refine(sc *mib.SyntaxConstraints) Constraints {
+	if sc == nil {
+		return c
+	}
+	if len(sc.Ranges) > 0 {
+		c.Ranges = sc.Ranges
+	}
+	if len(sc.Sizes) > 0 {
+		c.Sizes = sc.Sizes
+	}
+	if len(sc.Enums) > 0 {
+		c.Enums = sc.Enums
+	}
+	if len(sc.Bits) > 0 {
+		c.Bits = sc.Bits
+	}
+	return c
+}
+
+// CheckValue reports how a varbind value falls outside c, or "" when it
+// conforms. Exceptions (noSuchObject and friends) are not checked.
+func CheckValue(pdu gosnmp.SnmpPDU, c Constraints) string {
+	if !HasValue(pdu) {
+		return ""
+	}
+
+	if v, ok := IntegerValue(pdu); ok && pdu.Type != gosnmp.Counter64 {
+		if len(c.Enums) > 0 {
+			if !slices.ContainsFunc(c.Enums, func(nv mib.NamedValue) bool { return nv.Value == v }) {
+				return fmt.Sprintf("%d is not a defined enumeration", v)
+			}
+			return ""
+		}
+		if err := checkRanges(v, c.Ranges); err != nil {
+			return fmt.Sprintf("%d %v", v, err)
+		}
+		return ""
+	}
+
+	b, ok := pdu.Value.([]byte)
+	if !ok || pdu.Type != gosnmp.OctetString {
+		return ""
+	}
+	if len(c.Bits) > 0 {
+		return checkBits(b, c.Bits)
+	}
+	if len(c.Sizes) > 0 {
+		if err := checkRanges(int64(len(b)), c.Sizes); err != nil {
+			return fmt.Sprintf("length %d %v", len(b), err)
+		}
+	}
+	return ""
+}
+
+// checkBits reports set bits that have no named value.
+func checkBits(data []byte, bits []mib.NamedValue) string {
+	for i, octet := range data {
+		for j := range 8 {
+			if octet&(0x80>>j) == 0 {
+				continue
+			}
+			n := int64(i*8 + j)
+			if !slices.ContainsFunc(bits, func(nv mib.NamedValue) bool { return nv.Value == n }) {
+				return fmt.Sprintf("bit %d is not defined", n)
+			}
+		}
+	}
+	return ""
+}
+
+// ObjectViolation checks an instance of obj against the object's declared
+// syntax: the ASN.1 type on the wire, ranges, SIZE, enumerations, BITS, and
+// whether the DISPLAY-HINT can render the value. It returns a description
+// of the first problem found, or "" when the value conforms.
+func ObjectViolation(pdu gosnmp.SnmpPDU, obj *mib.Object) string {
+	if obj == nil || !HasValue(pdu) {
+		return ""
+	}
+	base := baseOf(obj)
+	if want := expectedTypes(base); len(want) > 0 && !slices.Contains(want, pdu.Type) {
+		return fmt.Sprintf("%s on the wire, syntax is %s", pduTypeName(pdu.Type), base)
+	}
+	if problem := CheckValue(pdu, ObjectConstraints(obj)); problem != "" {
+		return problem
+	}
+	if pdu.Type == gosnmp.OctetString && base != mib.BaseBits {
+		hint := obj.EffectiveDisplayHint()
+		if b, ok := pdu.Value.([]byte); ok && hint != "" && len(b) > 0 {
+			if _, ok := applyDisplayHint(hint, b); !ok {
+				return fmt.Sprintf("DISPLAY-HINT %q cannot format %d octets", hint, len(b))
+			}
+		}
+	}
+	return ""
+}
+
+// expectedTypes returns the ASN.1 types an agent may use for a base type,
+// or nil when the base type does not constrain the encoding.
+func expectedTypes(base mib.BaseType) []gosnmp.Asn1BER {
+	switch base {
+	case mib.BaseInteger32:
+		return []gosnmp.Asn1BER{gosnmp.Integer}
+	case mib.BaseUnsigned32, mib.BaseGauge32:
+		return []gosnmp.Asn1BER{gosnmp.Gauge32, gosnmp.Uinteger32}
+	case mib.BaseCounter32:
+		return []gosnmp.Asn1BER{gosnmp.Counter32}
+	case mib.BaseCounter64:
+		return []gosnmp.Asn1BER{gosnmp.Counter64}
+	case mib.BaseTimeTicks:
+		return []gosnmp.Asn1BER{gosnmp.TimeTicks}
+	case mib.BaseIpAddress:
+		return []gosnmp.Asn1BER{gosnmp.IPAddress}
+	case mib.BaseOctetString, mib.BaseBits:
+		return []gosnmp.Asn1BER{gosnmp.OctetString}
+	case mib.BaseObjectIdentifier:
+		return []gosnmp.Asn1BER{gosnmp.ObjectIdentifier}
+	case mib.BaseOpaque:
+		return []gosnmp.Asn1BER{gosnmp.Opaque, gosnmp.OpaqueFloat, gosnmp.OpaqueDouble}
+	}
+	return nil
+}
+
+// instanceObject returns the scalar or column object a varbind is an
+// instance of, or nil when node is not such an object or the OID has no
+// instance suffix.
+func instanceObject(node *mib.Node, oid mib.OID) *mib.Object {
+	if node == nil || len(oid) <= len(node.OID()) {
+		return nil
+	}
+	obj := node.Object()
+	if obj == nil || (!obj.IsScalar() && !obj.IsColumn()) {
+		return nil
+	}
+	return obj
+}
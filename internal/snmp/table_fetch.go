@@ -0,0 +1,268 @@
+package snmp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
+)
+
+// tableFetchWorkers is the default bound on how many columns are walked at
+// once. Each worker past the first opens its own connection to the agent.
+const tableFetchWorkers = 4
+
+// TableCell is one fetched value of a table, addressed by row suffix and
+// column position.
+type TableCell struct {
+	Suffix    string // index portion of the instance OID
+	Col       int    // position in the table's column list
+	Text      string // formatted value
+	PDU       gosnmp.SnmpPDU
+	Violation string // out-of-spec description, "" if conforming
+}
+
+// TableFetch tracks an in-progress column-wise table fetch.
+type TableFetch struct {
+	TableName string
+	Columns   []string // all column names, in table order
+	IndexCols int      // number of leading index columns
+	Fetching  int      // number of columns being walked
+	Ch        <-chan tableBatch
+	Cancel    context.CancelFunc
+}
+
+// tableBatch carries cells from one column, a column completion, or the
+// end of the fetch.
+type tableBatch struct {
+	cells      []TableCell
+	columnDone bool
+	done       bool
+	err        error
+}
+
+// TableBatchMsg carries table fetch progress to the update loop.
+type TableBatchMsg struct {
+	Cells       []TableCell
+	ColumnsDone int // columns completed since the previous message
+	Done        bool
+	Err         error
+}
+
+// StartTableFetchCmd fetches a table column by column, walking as many
+// columns concurrently as the session's parallelism allows, and streams the cells as they
+// arrive. Only the named columns are walked; nil walks them all. Each
+// handled batch must re-issue WaitTableCmd until done.
+func StartTableFetchCmd(sess *Session, tbl *mib.Object, only []string, m *mib.Mib) (*TableFetch, tea.Cmd) {
+	schema := buildTableSchema(tbl)
+	var cols []*tableColInfo
+	for _, ci := range schema.colList {
+		if only == nil || slices.Contains(only, ci.name) {
+			cols = append(cols, ci)
+		}
+	}
+
+	ch := make(chan tableBatch, 16)
+	stop, stopFetch := context.WithCancel(context.Background())
+	tf := &TableFetch{
+		TableName: tbl.Name(),
+		Columns:   schema.colNames,
+		IndexCols: schema.indexCols,
+		Fetching:  len(cols),
+		Ch:        ch,
+		Cancel:    stopFetch,
+	}
+
+	if !sess.IsConnected() || len(cols) == 0 {
+		err := errors.New("not connected")
+		if len(cols) == 0 {
+			err = errors.New("no columns defined")
+		}
+		go func() {
+			ch <- tableBatch{done: true, err: err}
+			close(ch)
+		}()
+		return tf, WaitTableCmd(ch)
+	}
+
+	ctx, cancel := context.WithCancel(stop) // also cancelled when a column fails
+	go func() {
+		defer close(ch)
+		defer cancel()
+
+		send := func(b tableBatch) bool {
+			select {
+			case ch <- b:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var (
+			mu       sync.Mutex
+			firstErr error
+		)
+		fail := func(err error) {
+			mu.Lock()
+			if firstErr == nil && !errors.Is(err, context.Canceled) {
+				firstErr = err
+			}
+			mu.Unlock()
+			cancel() // one failing column usually means the rest would too
+		}
+
+		jobs := make(chan *tableColInfo)
+		var wg sync.WaitGroup
+		for w := range max(1, min(sess.parallel, len(cols))) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Worker 0 walks on the session itself rather than a fork:
+				// a one-worker fetch (gentle mode, -parallel 1) then opens
+				// no second socket, and its requests still queue behind
+				// any other operation through the session's lock.
+				ws := sess
+				if w > 0 {
+					f, err := sess.fork()
+					if err != nil {
+						fail(sess.classify(err))
+						return
+					}
+					defer f.Close()
+					ws = f
+				}
+				for ci := range jobs {
+					var cells []TableCell
+					err := doWalk(ws, ci.oid, func(pdu gosnmp.SnmpPDU) error {
+						if ctx.Err() != nil {
+							return ctx.Err()
+						}
+						if c, ok := schema.cell(pdu, m); ok {
+							cells = append(cells, c)
+						}
+						if len(cells) >= walkBatchSize {
+							if !send(tableBatch{cells: cells}) {
+								return ctx.Err()
+							}
+							cells = nil
+						}
+						return nil
+					})
+					if err != nil {
+						send(tableBatch{cells: cells})
+						fail(err)
+						return
+					}
+					send(tableBatch{cells: cells, columnDone: true})
+				}
+			}()
+		}
+
+	feed:
+		for _, ci := range cols {
+			select {
+			case jobs <- ci:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(jobs)
+		wg.Wait()
+
+		err := firstErr
+		if err == nil {
+			err = stop.Err()
+		}
+		select {
+		case ch <- tableBatch{done: true, err: err}:
+		case <-stop.Done():
+		}
+	}()
+
+	return tf, WaitTableCmd(ch)
+}
+
+// TableDataMsg carries a whole table fetched by TableWalkCmd.
+type TableDataMsg struct {
+	TableName string
+	Columns   []string   // column names
+	Rows      []TableRow // rows in instance order
+	IndexCols int        // number of leading index columns
+	Err       error
+}
+
+// TableWalkCmd fetches a whole table and returns it in one message, for
+// callers that have no use for streamed progress. It drives the same
+// column-wise fetch as StartTableFetchCmd. Absent cells are left empty with
+// a zero PDU.
+func TableWalkCmd(sess *Session, tbl *mib.Object, m *mib.Mib) tea.Cmd {
+	return func() tea.Msg {
+		tf, wait := StartTableFetchCmd(sess, tbl, nil, m)
+		defer tf.Cancel()
+
+		msg := TableDataMsg{TableName: tf.TableName, Columns: tf.Columns, IndexCols: tf.IndexCols}
+		rowIndex := make(map[string]int)
+		for {
+			b := wait().(TableBatchMsg)
+			for _, c := range b.Cells {
+				i, ok := rowIndex[c.Suffix]
+				if !ok {
+					i = len(msg.Rows)
+					rowIndex[c.Suffix] = i
+					msg.Rows = append(msg.Rows, TableRow{
+						Suffix:     c.Suffix,
+						Cells:      make([]string, len(tf.Columns)),
+						PDUs:       make([]gosnmp.SnmpPDU, len(tf.Columns)),
+						Violations: make([]string, len(tf.Columns)),
+					})
+				}
+				row := &msg.Rows[i]
+				row.Cells[c.Col] = c.Text
+				row.PDUs[c.Col] = c.PDU
+				row.Violations[c.Col] = c.Violation
+			}
+			if b.Done {
+				msg.Err = b.Err
+				break
+			}
+		}
+		slices.SortStableFunc(msg.Rows, func(a, b TableRow) int {
+			return CompareOIDStrings(a.Suffix, b.Suffix)
+		})
+		return msg
+	}
+}
+
+// WaitTableCmd returns a command that blocks until table fetch progress is
+// ready, then collects any further batches already queued so that a fast
+// agent does not cost a redraw per batch.
+func WaitTableCmd(ch <-chan tableBatch) tea.Cmd {
+	return func() tea.Msg {
+		var msg TableBatchMsg
+		b, ok := <-ch
+		for {
+			if !ok {
+				// Closed without a final batch: the fetch was cancelled.
+				msg.Done, msg.Err = true, context.Canceled
+				return msg
+			}
+			msg.Cells = append(msg.Cells, b.cells...)
+			if b.columnDone {
+				msg.ColumnsDone++
+			}
+			if b.done {
+				msg.Done, msg.Err = true, b.err
+				return msg
+			}
+			select {
+			case b, ok = <-ch:
+			default:
+				return msg
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package snmp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/gosnmp/gosnmp"
+)
+
+// OID order policies for agents whose GETNEXT does not always return a
+// greater OID.
+const (
+	OIDOrderStrict  = "strict"  // stop the walk with an error
+	OIDOrderLenient = "lenient" // keep walking; stop only if the agent loops
+)
+
+// defaultMaxRepetitions matches gosnmp's GETBULK default.
+const defaultMaxRepetitions = 50
+
+// walkSubtree walks the subtree under root, starting after from (or at
+// root when from is empty), and calls fn for each instance. Requests are
+// labelled op in the wire log. It replaces
+// gosnmp's Walk and BulkWalk so that a walk can resume part-way through,
+// drop from GETBULK to GETNEXT when the agent answers tooBig, and apply
+// the session's OID order policy.
+func (s *Session) walkSubtree(op, root, from string, fn gosnmp.WalkFunc) error {
+	client := s.client
+	root = "." + strings.TrimPrefix(root, ".")
+	cursor := root
+	if from != "" {
+		cursor = "." + strings.TrimPrefix(from, ".")
+	}
+	bulk := client.Version != gosnmp.Version1
+	maxReps := client.MaxRepetitions
+	if maxReps == 0 {
+		maxReps = defaultMaxRepetitions
+	}
+	lenient := s.oidOrder == OIDOrderLenient
+
+	var prev mib.OID
+	if from != "" {
+		prev, _ = mib.ParseOID(cursor)
+	}
+	var seen map[string]bool
+	if lenient {
+		seen = make(map[string]bool)
+	}
+	first := from == ""
+
+	for {
+		pkt, err := s.request(op, func(client *gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error) {
+			if bulk {
+				return client.GetBulk([]string{cursor}, 0, maxReps)
+			}
+			return client.GetNext([]string{cursor})
+		})
+		if err != nil {
+			return err
+		}
+		switch {
+		case pkt.Error == gosnmp.TooBig && bulk:
+			bulk = false // the agent can't fit a bulk response; go one at a time
+			continue
+		case pkt.Error == gosnmp.NoSuchName:
+			return nil // SNMPv1 end of MIB view
+		case pkt.Error != gosnmp.NoError:
+			return packetError(pkt)
+		case len(pkt.Variables) == 0:
+			return nil
+		}
+
+		for i, pdu := range pkt.Variables {
+			switch pdu.Type {
+			case gosnmp.EndOfMibView, gosnmp.NoSuchObject, gosnmp.NoSuchInstance:
+				return nil
+			}
+			if !strings.HasPrefix(pdu.Name, root+".") {
+				if first && i == 0 {
+					// Walking an instance rather than a subtree: GET it.
+					return s.getInstance(op, root, fn)
+				}
+				return nil
+			}
+			first = false
+
+			oid, err := mib.ParseOID(pdu.Name)
+			if err != nil {
+				return err
+			}
+			if prev != nil && oid.Compare(prev) <= 0 && !lenient {
+				return orderError(pdu.Name, prev.String(), false)
+			}
+			if lenient {
+				if seen[pdu.Name] {
+					return orderError(pdu.Name, prev.String(), true)
+				}
+				seen[pdu.Name] = true
+			}
+			if err := fn(pdu); err != nil {
+				return err
+			}
+			prev = oid
+			cursor = pdu.Name
+		}
+	}
+}
+
+// getInstance fetches root itself, for walks aimed at a single instance.
+func (s *Session) getInstance(op, root string, fn gosnmp.WalkFunc) error {
+	pkt, err := s.request(op, func(client *gosnmp.GoSNMP) (*gosnmp.SnmpPacket, error) {
+		return client.Get([]string{root})
+	})
+	if err != nil {
+		return err
+	}
+	for _, pdu := range pkt.Variables {
+		if HasValue(pdu) {
+			if err := fn(pdu); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// orderError reports an agent returning an OID that does not follow the
+// previous one, or, under the lenient policy, one it already returned.
+func orderError(got, after string, looped bool) error {
+	e := &Error{
+		Kind:    KindNotIncreasing,
+		Summary: "the agent returned OIDs out of order",
+		VarBind: strings.TrimPrefix(got, "."),
+		Causes: []string{
+			"an agent bug in one table's GETNEXT ordering",
+			"set the connection's OID order to lenient to keep walking",
+		},
+		Err: fmt.Errorf("%s does not follow %s", strings.TrimPrefix(got, "."), after),
+	}
+	if looped {
+		e.Summary = "the agent looped back to an OID it already returned"
+		e.Causes = []string{
+			"an agent bug in one table's GETNEXT ordering",
+			"walk the subtrees after the faulty table separately",
+		}
+	}
+	return e
+}
@@ -20,10 +20,11 @@ type WatchTickMsg struct {
 	Seq uint64 // sequence number, ignored if stale
 }
 
-// WatchPollCmd performs a synchronous walk in a goroutine and returns all
+// WatchPollCmd walks each root in turn in a goroutine and returns all
 // PDUs at once via WatchPollMsg. Unlike the streaming walk, this collects
-// everything before sending a single message.
-func WatchPollCmd(sess *Session, rootOID string, seq uint64) tea.Cmd {
+// everything before sending a single message. A root naming a single
+// instance is fetched with GET.
+func WatchPollCmd(sess *Session, roots []string, seq uint64) tea.Cmd {
 	return func() tea.Msg {
 		if !sess.IsConnected() {
 			return WatchPollMsg{Seq: seq, Err: errors.New("not connected")}
@@ -35,8 +36,12 @@ func WatchPollCmd(sess *Session, rootOID string, seq uint64) tea.Cmd {
 			return nil
 		}
 
-		err := doWalk(sess.client, rootOID, walkFn)
-		return WatchPollMsg{Seq: seq, PDUs: pdus, Err: err}
+		for _, root := range roots {
+			if err := doWalk(sess, root, walkFn); err != nil {
+				return WatchPollMsg{Seq: seq, PDUs: pdus, Err: err}
+			}
+		}
+		return WatchPollMsg{Seq: seq, PDUs: pdus}
 	}
 }
 
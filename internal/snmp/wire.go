@@ -0,0 +1,150 @@
+package snmp
+
+import (
+	"net"
+	"slices"
+	"sync"
+	"time"
+)
+
+// wireLogSize is the number of request/response exchanges kept per session.
+const wireLogSize = 32
+
+// Exchange is one request sent to the agent and the response it got, as
+// raw BER. Response is nil when the request timed out or is still
+// outstanding; a retry is a separate exchange.
+type Exchange struct {
+	Seq      uint64
+	Op       string // operation that sent the request, e.g. "walk 1.3.6.1.2.1.2"
+	Sent     time.Time
+	Request  []byte
+	Response []byte
+	RTT      time.Duration // zero until a response arrives
+}
+
+// WireLog records the most recent exchanges on a session. It is written
+// from command goroutines and read from the update loop, so all access
+// goes through its mutex.
+type WireLog struct {
+	mu        sync.Mutex
+	op        string
+	seq       uint64
+	exchanges []Exchange // oldest first, at most wireLogSize
+}
+
+// setOp labels the exchanges sent from now on.
+func (w *WireLog) setOp(op string) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.op = op
+	w.mu.Unlock()
+}
+
+func (w *WireLog) sent(b []byte) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.seq++
+	w.exchanges = append(w.exchanges, Exchange{
+		Seq:     w.seq,
+		Op:      w.op,
+		Sent:    time.Now(),
+		Request: slices.Clone(b),
+	})
+	if len(w.exchanges) > wireLogSize {
+		w.exchanges = slices.Delete(w.exchanges, 0, len(w.exchanges)-wireLogSize)
+	}
+}
+
+// received pairs a response with the request carrying the same message
+// identifier, so a late answer to a timed-out attempt lands on that
+// attempt rather than on the retry. Unmatched responses go to the newest
+// outstanding request.
+func (w *WireLog) received(b []byte) {
+	if w == nil {
+		return
+	}
+	now := time.Now()
+	key, keyed := messageKey(b)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	target := -1
+	for i := len(w.exchanges) - 1; i >= 0; i-- {
+		ex := &w.exchanges[i]
+		if ex.Response != nil {
+			continue
+		}
+		if target < 0 {
+			target = i
+		}
+		if !keyed {
+			break
+		}
+		if k, ok := messageKey(ex.Request); ok && k == key {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		return
+	}
+	ex := &w.exchanges[target]
+	ex.Response = slices.Clone(b)
+	ex.RTT = now.Sub(ex.Sent)
+}
+
+// Exchanges returns a copy of the recorded exchanges, newest first.
+func (w *WireLog) Exchanges() []Exchange {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := slices.Clone(w.exchanges)
+	slices.Reverse(out)
+	return out
+}
+
+// wireConn wraps the client's connection: it paces writes through the
+// session's limiter and copies every datagram into the session's wire log.
+// It deliberately does not implement net.PacketConn, so gosnmp uses Write
+// and Read on the connected socket.
+type wireConn struct {
+	net.Conn
+	log      *WireLog
+	limit    *limiter
+	deadline time.Time // last deadline gosnmp set, pushed back by pacing
+}
+
+// SetDeadline records the request deadline so time spent waiting for the
+// limiter does not count against the request's timeout.
+func (c *wireConn) SetDeadline(t time.Time) error {
+	c.deadline = t
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *wireConn) Write(b []byte) (int, error) {
+	if d := c.limit.wait(); d > 0 && !c.deadline.IsZero() {
+		if err := c.Conn.SetDeadline(c.deadline.Add(d)); err != nil {
+			return 0, err
+		}
+	}
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.log.sent(b[:n])
+	}
+	return n, err
+}
+
+func (c *wireConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.log.received(b[:n])
+	}
+	return n, err
+}
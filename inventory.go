@@ -0,0 +1,505 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+const inventoryHeaderLines = 2 // header + underline
+
+// Further entPhysicalTable columns, and the ENTITY-SENSOR-MIB sensor table,
+// which shares entPhysicalIndex.
+const (
+	oidEntPhysicalContainedIn  = "1.3.6.1.2.1.47.1.1.1.1.4"
+	oidEntPhysicalParentRelPos = "1.3.6.1.2.1.47.1.1.1.1.6"
+	oidEntPhysicalName         = "1.3.6.1.2.1.47.1.1.1.1.7"
+	oidEntPhysicalHardwareRev  = "1.3.6.1.2.1.47.1.1.1.1.8"
+	oidEntPhysicalFirmwareRev  = "1.3.6.1.2.1.47.1.1.1.1.9"
+
+	oidEntPhySensorType         = "1.3.6.1.2.1.99.1.1.1.1"
+	oidEntPhySensorScale        = "1.3.6.1.2.1.99.1.1.1.2"
+	oidEntPhySensorPrecision    = "1.3.6.1.2.1.99.1.1.1.3"
+	oidEntPhySensorValue        = "1.3.6.1.2.1.99.1.1.1.4"
+	oidEntPhySensorOperStatus   = "1.3.6.1.2.1.99.1.1.1.5"
+	oidEntPhySensorUnitsDisplay = "1.3.6.1.2.1.99.1.1.1.6"
+)
+
+// inventoryOIDs returns the columns an inventory collection walks.
+func inventoryOIDs() []string {
+	return []string{
+		oidEntPhysicalDescr, oidEntPhysicalContainedIn, oidEntPhysicalClass,
+		oidEntPhysicalParentRelPos, oidEntPhysicalName, oidEntPhysicalHardwareRev,
+		oidEntPhysicalFirmwareRev, oidEntPhysicalSoftwareRev, oidEntPhysicalSerialNum,
+		oidEntPhysicalModelName,
+		oidEntPhySensorType, oidEntPhySensorScale, oidEntPhySensorPrecision,
+		oidEntPhySensorValue, oidEntPhySensorOperStatus, oidEntPhySensorUnitsDisplay,
+	}
+}
+
+// entityClassIcons marks each PhysicalClass in the inventory tree.
+var entityClassIcons = map[int64]string{
+	3:  "▣", // chassis: square in square
+	4:  "≡", // backplane: triple bar
+	5:  "□", // container: empty square
+	6:  "⌁", // powerSupply: electric arrow
+	7:  "✻", // fan: teardrop asterisk
+	8:  "◉", // sensor: fisheye
+	9:  "▤", // module: square with horizontal fill
+	10: "○", // port: circle
+	11: "▦", // stack: square with crosshatch
+	12: "▩", // cpu: square with diagonal crosshatch
+	13: "⌁", // energyObject: electric arrow
+	14: "▮", // battery: vertical rectangle
+	15: "▥", // storageDrive: square with vertical fill
+}
+
+// entitySensorScales are the EntitySensorDataScale powers of ten. The
+// enumeration runs exa(14) before peta(15).
+var entitySensorScales = map[int64]int{
+	1: -24, 2: -21, 3: -18, 4: -15, 5: -12, 6: -9, 7: -6, 8: -3, 9: 0,
+	10: 3, 11: 6, 12: 9, 13: 12, 14: 18, 15: 15, 16: 21, 17: 24,
+}
+
+// entitySensorUnits are the units of the EntitySensorDataType values that
+// have fixed units.
+var entitySensorUnits = map[int64]string{
+	3: "V AC", 4: "V DC", 5: "A", 6: "W", 7: "Hz", 8: "°C", 9: "%RH", 10: "rpm", 11: "cmm",
+}
+
+// entity is one entPhysicalTable row placed in the containment tree.
+type entity struct {
+	index    int64
+	class    int64
+	name     string // entPhysicalName, or entPhysicalDescr when unnamed
+	model    string
+	serial   string
+	hwRev    string
+	fwRev    string
+	swRev    string
+	parent   int64  // entPhysicalContainedIn, 0 at the top
+	relPos   int64  // entPhysicalParentRelPos, -1 when not applicable
+	sensor   string // formatted sensor reading, "" if the entity has none
+	sensorOK bool   // entPhySensorOperStatus is ok
+	children []*entity
+	expanded bool
+}
+
+// inventoryRow is a single visible row in the flattened inventory tree.
+type inventoryRow struct {
+	ent     *entity
+	depth   int
+	hasKids bool
+}
+
+// inventoryModel shows an agent's physical entities as a containment tree.
+type inventoryModel struct {
+	target  string
+	loading bool
+	label   string // CollectCmd label of the in-flight collection
+	roots   []*entity
+	count   int    // entities
+	sensors int    // entities with a sensor reading
+	errText string // why the collection stopped early, "" if complete
+
+	lv      ListView[inventoryRow]
+	width   int
+	focused bool // set during view() to control selection style
+}
+
+func newInventoryModel() inventoryModel {
+	return inventoryModel{lv: NewListView[inventoryRow](inventoryHeaderLines)}
+}
+
+// start clears the tree for a new collection from target.
+func (v *inventoryModel) start(target string) {
+	v.target = target
+	v.loading = true
+	v.label = "inventory " + target
+	v.roots = nil
+	v.count = 0
+	v.sensors = 0
+	v.errText = ""
+	v.lv.SetRows(nil)
+}
+
+// load builds the containment tree from collected columns.
+func (v *inventoryModel) load(pdus map[string][]gosnmp.SnmpPDU, err error, m *mib.Mib) {
+	v.loading = false
+	v.errText = ""
+	if err != nil {
+		v.errText = snmp.ErrorText(err, m)
+	}
+
+	col := func(oid string) map[string]gosnmp.SnmpPDU { return summaryColumn(pdus, oid) }
+	class := col(oidEntPhysicalClass)
+	descr, name := col(oidEntPhysicalDescr), col(oidEntPhysicalName)
+	contained, relPos := col(oidEntPhysicalContainedIn), col(oidEntPhysicalParentRelPos)
+	model, serial := col(oidEntPhysicalModelName), col(oidEntPhysicalSerialNum)
+	hw, fw, sw := col(oidEntPhysicalHardwareRev), col(oidEntPhysicalFirmwareRev), col(oidEntPhysicalSoftwareRev)
+
+	text := func(c map[string]gosnmp.SnmpPDU, suffix string) string {
+		pdu, ok := c[suffix]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(snmp.FormatPDUToResult(pdu, m).Value)
+	}
+	integer := func(c map[string]gosnmp.SnmpPDU, suffix string) int64 {
+		n, _ := snmp.IntegerValue(c[suffix])
+		return n
+	}
+
+	// Entities are whatever entPhysicalIndex values the class or
+	// description columns returned.
+	all := make(map[string]gosnmp.SnmpPDU)
+	for _, c := range []map[string]gosnmp.SnmpPDU{class, descr} {
+		for suffix, pdu := range c {
+			all[suffix] = pdu
+		}
+	}
+
+	sensors := entitySensorColumns{
+		typ:       col(oidEntPhySensorType),
+		scale:     col(oidEntPhySensorScale),
+		precision: col(oidEntPhySensorPrecision),
+		value:     col(oidEntPhySensorValue),
+		status:    col(oidEntPhySensorOperStatus),
+		units:     col(oidEntPhySensorUnitsDisplay),
+	}
+
+	byIndex := make(map[int64]*entity, len(all))
+	var ents []*entity
+	for _, suffix := range sortedSuffixes(all) {
+		idx, err := strconv.ParseInt(suffix, 10, 64)
+		if err != nil {
+			continue
+		}
+		e := &entity{
+			index:    idx,
+			class:    integer(class, suffix),
+			name:     text(name, suffix),
+			model:    text(model, suffix),
+			serial:   text(serial, suffix),
+			hwRev:    text(hw, suffix),
+			fwRev:    text(fw, suffix),
+			swRev:    text(sw, suffix),
+			parent:   integer(contained, suffix),
+			relPos:   -1,
+			expanded: true,
+		}
+		if p, ok := relPos[suffix]; ok {
+			e.relPos, _ = snmp.IntegerValue(p)
+		}
+		if e.name == "" {
+			e.name = text(descr, suffix)
+		}
+		e.sensor, e.sensorOK = sensors.reading(suffix)
+		if e.sensor != "" {
+			v.sensors++
+		}
+		byIndex[idx] = e
+		ents = append(ents, e)
+	}
+	v.count = len(ents)
+
+	for _, e := range ents {
+		if p, ok := byIndex[e.parent]; ok && p != e {
+			p.children = append(p.children, e)
+		}
+	}
+	// Roots are top-level entities, those whose container the agent did
+	// not return, and any caught in a containment loop.
+	reached := make(map[*entity]bool, len(ents))
+	var mark func(e *entity)
+	mark = func(e *entity) {
+		reached[e] = true
+		for _, c := range e.children {
+			if !reached[c] {
+				mark(c)
+			}
+		}
+	}
+	var roots []*entity
+	for _, e := range ents {
+		if p, ok := byIndex[e.parent]; !ok || p == e {
+			roots = append(roots, e)
+			mark(e)
+		}
+	}
+	for _, e := range ents {
+		if !reached[e] {
+			p := byIndex[e.parent]
+			p.children = slices.DeleteFunc(p.children, func(c *entity) bool { return c == e })
+			roots = append(roots, e)
+			mark(e)
+		}
+	}
+
+	sortEntities(roots)
+	v.roots = roots
+	v.rebuild()
+	v.lv.GoTop()
+}
+
+// sortEntities orders siblings by entPhysicalParentRelPos, then index.
+func sortEntities(ents []*entity) {
+	slices.SortFunc(ents, func(a, b *entity) int {
+		if c := cmp.Compare(a.relPos, b.relPos); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.index, b.index)
+	})
+	for _, e := range ents {
+		sortEntities(e.children)
+	}
+}
+
+// entitySensorColumns holds the walked entPhySensorTable, indexed by
+// entPhysicalIndex suffix.
+type entitySensorColumns struct {
+	typ, scale, precision, value, status, units map[string]gosnmp.SnmpPDU
+}
+
+// reading formats an entity's sensor value with its scale, precision and
+// units, and reports whether the sensor is ok. It returns "" for entities
+// without a sensor.
+func (c entitySensorColumns) reading(suffix string) (string, bool) {
+	valPDU, ok := c.value[suffix]
+	if !ok {
+		return "", false
+	}
+	val, _ := snmp.IntegerValue(valPDU)
+	var typ, scale, prec, status int64 = 0, 9, 0, 1
+	if p, ok := c.typ[suffix]; ok {
+		typ, _ = snmp.IntegerValue(p)
+	}
+	if p, ok := c.scale[suffix]; ok {
+		scale, _ = snmp.IntegerValue(p)
+	}
+	if p, ok := c.precision[suffix]; ok {
+		prec, _ = snmp.IntegerValue(p)
+	}
+	if p, ok := c.status[suffix]; ok {
+		status, _ = snmp.IntegerValue(p)
+	}
+
+	var reading string
+	if typ == 12 { // truthvalue
+		reading = map[int64]string{1: "true", 2: "false"}[val]
+	} else {
+		exp := entitySensorScales[scale] - int(prec)
+		decimals := max(0, min(6, -exp))
+		reading = strconv.FormatFloat(float64(val)*math.Pow10(exp), 'f', decimals, 64)
+		units, ok := entitySensorUnits[typ]
+		if !ok {
+			if b, ok := c.units[suffix].Value.([]byte); ok {
+				units = strings.TrimSpace(string(b))
+			}
+		}
+		if units != "" {
+			reading += " " + units
+		}
+	}
+	switch status {
+	case 1:
+		return reading, true
+	case 2:
+		return reading + " (unavailable)", false
+	case 3:
+		return reading + " (nonoperational)", false
+	}
+	return reading, false
+}
+
+// rebuild flattens the tree according to the current expanded state.
+func (v *inventoryModel) rebuild() {
+	var rows []inventoryRow
+	var flatten func(e *entity, depth int)
+	flatten = func(e *entity, depth int) {
+		rows = append(rows, inventoryRow{ent: e, depth: depth, hasKids: len(e.children) > 0})
+		if e.expanded {
+			for _, c := range e.children {
+				flatten(c, depth+1)
+			}
+		}
+	}
+	for _, e := range v.roots {
+		flatten(e, 0)
+	}
+	v.lv.SetRows(rows)
+}
+
+// toggle expands or collapses the entity at the cursor.
+func (v *inventoryModel) toggle() {
+	sel := v.lv.Selected()
+	if sel == nil || !sel.hasKids {
+		return
+	}
+	sel.ent.expanded = !sel.ent.expanded
+	v.rebuild()
+}
+
+// expand expands the entity at the cursor.
+func (v *inventoryModel) expand() {
+	sel := v.lv.Selected()
+	if sel == nil || !sel.hasKids || sel.ent.expanded {
+		return
+	}
+	sel.ent.expanded = true
+	v.rebuild()
+}
+
+// collapse collapses the entity at the cursor, or moves to its container.
+func (v *inventoryModel) collapse() {
+	sel := v.lv.Selected()
+	if sel == nil {
+		return
+	}
+	if sel.hasKids && sel.ent.expanded {
+		sel.ent.expanded = false
+		v.rebuild()
+		return
+	}
+	cursor := v.lv.Cursor()
+	for i := cursor - 1; i >= 0; i-- {
+		if v.lv.Row(i).depth < sel.depth {
+			v.lv.SetCursor(i)
+			return
+		}
+	}
+}
+
+// selected returns the entity under the cursor, or nil.
+func (v *inventoryModel) selected() *entity {
+	if sel := v.lv.Selected(); sel != nil {
+		return sel.ent
+	}
+	return nil
+}
+
+func (v *inventoryModel) setSize(width, height int) {
+	v.width = width
+	v.lv.SetSize(width, height)
+}
+
+func (v *inventoryModel) clickRow(row int) {
+	if row >= 0 && row < v.lv.Len() {
+		v.lv.SetCursor(row)
+	}
+}
+
+// view renders the inventory pane content.
+func (v *inventoryModel) view(focused bool) string {
+	v.focused = focused
+	if v.target == "" {
+		return styles.EmptyText.Render("(no inventory collected)")
+	}
+
+	var b strings.Builder
+	header := fmt.Sprintf("INVENTORY %s", v.target)
+	if v.loading {
+		header += " " + IconLoading
+	} else {
+		header += fmt.Sprintf(" | %d entities | %d sensors", v.count, v.sensors)
+	}
+	b.WriteString(styles.Header.Info.Render(header))
+	if v.errText != "" {
+		b.WriteString("  " + styles.Status.WarnMsg.Render("incomplete: "+v.errText))
+	}
+	b.WriteByte('\n')
+
+	if v.lv.Len() == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(styles.EmptyText.Render(IconLoading + " Reading entPhysicalTable..."))
+		default:
+			b.WriteString(styles.EmptyText.Render("(the agent returned no entPhysicalTable)"))
+		}
+		return b.String()
+	}
+
+	b.WriteString(styles.Header.Underline.Render(strings.Repeat("─", min(lipgloss.Width(header)+4, v.width))))
+	b.WriteByte('\n')
+
+	b.WriteString(v.lv.Render(v.renderRowFn))
+	return b.String()
+}
+
+// renderRowFn is the RenderFunc for inventoryModel.
+func (v *inventoryModel) renderRowFn(row inventoryRow, _ int, selected bool, width int) string {
+	e := row.ent
+	indent := strings.Repeat("  ", row.depth)
+	icon := treeIcon(row.hasKids, e.expanded)
+	classIcon, ok := entityClassIcons[e.class]
+	if !ok {
+		classIcon = IconPending
+	}
+	details := e.details()
+
+	if selected && v.focused {
+		bg := styles.Tree.SelectedBg
+		selBg := bg.GetBackground()
+		sp := bg.Render(" ")
+		content := bg.Render(indent+icon) + styles.Label.Background(selBg).Render(classIcon) + sp +
+			styles.Value.Background(selBg).Render(e.name)
+		if details != "" {
+			content += sp + sp + styles.Label.Background(selBg).Render(details)
+		}
+		if e.sensor != "" {
+			content += sp + sp + sensorStyle(e.sensorOK).Background(selBg).Render(e.sensor)
+		}
+		return renderSelectedLine(content, width, true)
+	}
+
+	content := indent + icon + styles.Label.Render(classIcon) + " " + styles.Value.Render(e.name)
+	if details != "" {
+		content += "  " + styles.Label.Render(details)
+	}
+	if e.sensor != "" {
+		content += "  " + sensorStyle(e.sensorOK).Render(e.sensor)
+	}
+	if selected {
+		return renderSelectedLine(content, width, false)
+	}
+	return "  " + content
+}
+
+// details lists the entity's class, model, serial number and revisions.
+func (e *entity) details() string {
+	var parts []string
+	if name, ok := entPhysicalClassNames[e.class]; ok {
+		parts = append(parts, name)
+	}
+	if e.model != "" {
+		parts = append(parts, e.model)
+	}
+	if e.serial != "" {
+		parts = append(parts, "SN "+e.serial)
+	}
+	if e.hwRev != "" {
+		parts = append(parts, "HW "+e.hwRev)
+	}
+	if e.fwRev != "" {
+		parts = append(parts, "FW "+e.fwRev)
+	}
+	if e.swRev != "" {
+		parts = append(parts, "SW "+e.swRev)
+	}
+	return strings.Join(parts, "  ")
+}
+
+func sensorStyle(ok bool) lipgloss.Style {
+	if ok {
+		return styles.Status.SuccessMsg
+	}
+	return styles.Status.WarnMsg
+}
@@ -0,0 +1,454 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// lintConfigFile is read from the working directory when -config is not
+// given.
+const lintConfigFile = ".mibsh-lint.json"
+
+const lintUsage = `mibsh lint - check MIB files for problems
+
+Usage:
+  mibsh lint [options] PATH...
+
+Each PATH is a MIB file, a directory (searched recursively) or a .zip,
+.tar.gz or .tgz archive. Only modules from these paths are reported; the
+modules they import are loaded from -p paths or the system locations.
+
+Options:
+  -strictness LEVEL   strict, normal, permissive or silent (default "normal")
+  -format FORMAT      text, json or sarif (default "text")
+  -fail-on SEVERITY   exit 1 when a diagnostic is this severe or worse:
+                      fatal, severe, error, minor, style, warning, info,
+                      or none (default "error")
+  -config FILE        suppression config (default ` + lintConfigFile + ` if present)
+  -p PATH             search path for imported modules (repeatable)
+
+The config file is JSON. "ignore" lists diagnostic codes to suppress, with
+* and ? globs; "severity" changes the severity of a code:
+
+  {
+    "ignore": ["identifier-underscore", "identifier-length-*"],
+    "severity": {"revision-last-updated": "info"}
+  }
+
+Exit status is 0 when no diagnostic reaches -fail-on, 1 when one does, and
+2 when the MIBs could not be loaded.
+`
+
+// lintSuppressions is the lint config file.
+type lintSuppressions struct {
+	Ignore   []string          `json:"ignore"`
+	Severity map[string]string `json:"severity"`
+}
+
+// lintFinding is a diagnostic from a linted module, with the file it is in.
+type lintFinding struct {
+	mib.Diagnostic
+	file string
+}
+
+// runLint runs the lint subcommand, writing the report to stdout, and
+// returns the exit status.
+func runLint(args []string, stdout io.Writer) int {
+	var paths pathList
+	var strictness, format, failOn, configPath string
+
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, lintUsage) }
+	fs.Var(&paths, "p", "search path for imported modules (repeatable)")
+	fs.StringVar(&strictness, "strictness", "normal", "strict, normal, permissive or silent")
+	fs.StringVar(&format, "format", "text", "text, json or sarif")
+	fs.StringVar(&failOn, "fail-on", "error", "severity that fails the run, or none")
+	fs.StringVar(&configPath, "config", "", "suppression config file")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	targets := fs.Args()
+	if len(targets) == 0 {
+		fmt.Fprint(os.Stderr, lintUsage)
+		return 2
+	}
+
+	diagCfg, err := lintDiagConfig(strictness)
+	if err != nil {
+		return lintError(err)
+	}
+	if format != "text" && format != "json" && format != "sarif" {
+		return lintError(fmt.Errorf("unknown format %q", format))
+	}
+	threshold := mib.Severity(-1) // none
+	if failOn != "none" {
+		if threshold, err = parseSeverity(failOn); err != nil {
+			return lintError(err)
+		}
+	}
+	supp, err := loadLintSuppressions(configPath)
+	if err != nil {
+		return lintError(err)
+	}
+	overrides := make(map[string]mib.Severity, len(supp.Severity))
+	for code, name := range supp.Severity {
+		sev, err := parseSeverity(name)
+		if err != nil {
+			return lintError(fmt.Errorf("config: %s: %w", code, err))
+		}
+		overrides[code] = sev
+	}
+	diagCfg.Ignore = append(diagCfg.Ignore, supp.Ignore...)
+	diagCfg.Overrides = overrides
+
+	findings, modules, err := lintPaths(targets, paths, diagCfg)
+	if err != nil {
+		return lintError(err)
+	}
+
+	switch format {
+	case "json":
+		err = writeLintJSON(stdout, findings, modules)
+	case "sarif":
+		err = writeLintSARIF(stdout, findings)
+	default:
+		writeLintText(stdout, findings, modules)
+	}
+	if err != nil {
+		return lintError(err)
+	}
+
+	for _, f := range findings {
+		if f.Severity <= threshold {
+			return 1
+		}
+	}
+	return 0
+}
+
+func lintError(err error) int {
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	return 2
+}
+
+// lintDiagConfig returns the diagnostic preset for a strictness name. Load
+// failures are turned off; the -fail-on threshold decides the outcome.
+func lintDiagConfig(name string) (mib.DiagnosticConfig, error) {
+	levels := map[string]mib.StrictnessLevel{
+		"strict":     mib.StrictnessStrict,
+		"normal":     mib.StrictnessNormal,
+		"permissive": mib.StrictnessPermissive,
+		"silent":     mib.StrictnessSilent,
+	}
+	level, ok := levels[name]
+	if !ok {
+		return mib.DiagnosticConfig{}, fmt.Errorf("unknown strictness %q", name)
+	}
+	cfg := mib.ConfigForLevel(level)
+	cfg.FailAt = -1
+	return cfg, nil
+}
+
+// parseSeverity parses a severity name such as "error".
+func parseSeverity(name string) (mib.Severity, error) {
+	for sev := mib.SeverityFatal; sev <= mib.SeverityInfo; sev++ {
+		if sev.String() == name {
+			return sev, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// loadLintSuppressions reads the config at path, or the default file when
+// path is empty. A missing default file is not an error.
+func loadLintSuppressions(path string) (lintSuppressions, error) {
+	var supp lintSuppressions
+	explicit := path != ""
+	if !explicit {
+		path = lintConfigFile
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return supp, nil
+		}
+		return supp, err
+	}
+	if err := json.Unmarshal(data, &supp); err != nil {
+		return supp, fmt.Errorf("%s: %w", path, err)
+	}
+	return supp, nil
+}
+
+// lintFindingKey identifies a finding for removing repeats.
+type lintFindingKey struct {
+	code, module, message string
+	line, column          int
+}
+
+// lintPaths loads the modules in targets with their imports and returns
+// the diagnostics reported against them, most severe first, with the names
+// of the modules checked. gomib can report a problem more than once, such
+// as an unknown type once per compliance that refines the object; repeats
+// are dropped.
+func lintPaths(targets, searchPaths []string, diagCfg mib.DiagnosticConfig) ([]lintFinding, []string, error) {
+	var sources []gomib.Source
+	var names []string
+	roots := make([]string, len(targets))
+	for i, t := range targets {
+		root, err := filepath.Abs(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		src, err := extraMibSource(root)
+		if err != nil {
+			return nil, nil, err
+		}
+		list, err := src.ListModules()
+		if err != nil {
+			return nil, nil, err
+		}
+		roots[i] = root
+		sources = append(sources, src)
+		names = append(names, list...)
+	}
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("no MIB modules found in %s", strings.Join(targets, ", "))
+	}
+
+	pathOpt, err := searchPathOption(searchPaths, func(msg string) {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", msg)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := gomib.Load(context.Background(),
+		gomib.WithSource(sources...),
+		pathOpt,
+		gomib.WithDiagnosticConfig(diagCfg),
+		gomib.WithModules(names...),
+	)
+	if m == nil {
+		return nil, nil, err
+	}
+	if errors.Is(err, gomib.ErrMissingModules) {
+		// Files that do not parse as modules show up as diagnostics too.
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	files := make(map[string]string) // module name -> source file
+	var modules []string
+	for _, mod := range m.Modules() {
+		if slices.ContainsFunc(roots, func(root string) bool { return withinSource(mod.SourcePath(), root) }) {
+			files[mod.Name()] = mod.SourcePath()
+			modules = append(modules, mod.Name())
+		}
+	}
+
+	var findings []lintFinding
+	seen := make(map[lintFindingKey]bool)
+	for _, d := range m.Diagnostics() {
+		file, ok := files[d.Module]
+		if !ok {
+			continue
+		}
+		key := lintFindingKey{d.Code, d.Module, d.Message, d.Line, d.Column}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if sev, ok := diagCfg.Overrides[d.Code]; ok {
+			d.Severity = sev
+		}
+		findings = append(findings, lintFinding{Diagnostic: d, file: file})
+	}
+	slices.SortStableFunc(findings, func(a, b lintFinding) int {
+		return cmp.Or(cmp.Compare(a.Severity, b.Severity), cmp.Compare(a.file, b.file), cmp.Compare(a.Line, b.Line))
+	})
+	return findings, modules, nil
+}
+
+// displayPath shortens path relative to the working directory when it is
+// below it.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
+// writeLintText writes one "file:line:col: severity: message [code]" line
+// per finding, the form editors and CI logs link to, then a summary.
+func writeLintText(w io.Writer, findings []lintFinding, modules []string) {
+	counts := make(map[mib.Severity]int)
+	for _, f := range findings {
+		loc := displayPath(f.file)
+		if f.Line > 0 {
+			loc += fmt.Sprintf(":%d", f.Line)
+			if f.Column > 0 {
+				loc += fmt.Sprintf(":%d", f.Column)
+			}
+		}
+		fmt.Fprintf(w, "%s: %s: %s [%s]\n", loc, f.Severity, f.Message, f.Code)
+		counts[f.Severity]++
+	}
+
+	var parts []string
+	for sev := mib.SeverityFatal; sev <= mib.SeverityInfo; sev++ {
+		if counts[sev] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[sev], sev))
+		}
+	}
+	summary := fmt.Sprintf("%d modules checked, %d diagnostics", len(modules), len(findings))
+	if len(parts) > 0 {
+		summary += " (" + strings.Join(parts, ", ") + ")"
+	}
+	fmt.Fprintln(w, summary)
+}
+
+type lintJSONDiagnostic struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Module   string `json:"module"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+func writeLintJSON(w io.Writer, findings []lintFinding, modules []string) error {
+	out := struct {
+		Modules     []string             `json:"modules"`
+		Diagnostics []lintJSONDiagnostic `json:"diagnostics"`
+	}{Modules: modules, Diagnostics: []lintJSONDiagnostic{}}
+	for _, f := range findings {
+		out.Diagnostics = append(out.Diagnostics, lintJSONDiagnostic{
+			Severity: f.Severity.String(),
+			Code:     f.Code,
+			Message:  f.Message,
+			Module:   f.Module,
+			File:     displayPath(f.file),
+			Line:     f.Line,
+			Column:   f.Column,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// SARIF 2.1.0, the subset code scanning services read.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID string `json:"id"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifact `json:"artifactLocation"`
+		Region           *sarifRegion  `json:"region,omitempty"`
+	}
+	sarifArtifact struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+func writeLintSARIF(w io.Writer, findings []lintFinding) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "mibsh",
+			InformationURI: "https://github.com/golangsnmp/mibsh",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if !seen[f.Code] {
+			seen[f.Code] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: f.Code})
+		}
+		path := displayPath(f.file)
+		uri := filepath.ToSlash(path)
+		if filepath.IsAbs(path) {
+			uri = "file://" + uri // outside the working directory
+		}
+		loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: uri}}
+		if f.Line > 0 {
+			loc.Region = &sarifRegion{StartLine: f.Line, StartColumn: f.Column}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    f.Code,
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: f.Module + ": " + f.Message},
+			Locations: []sarifLocation{{PhysicalLocation: loc}},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifLevel maps a severity onto SARIF's error, warning and note.
+func sarifLevel(sev mib.Severity) string {
+	switch {
+	case sev <= mib.SeverityError:
+		return "error"
+	case sev <= mib.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
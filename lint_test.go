@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    mib.Severity
+		wantErr bool
+	}{
+		{"fatal", mib.SeverityFatal, false},
+		{"error", mib.SeverityError, false},
+		{"minor", mib.SeverityMinor, false},
+		{"info", mib.SeverityInfo, false},
+		{"ERROR", 0, true},
+		{"none", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSeverity(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSeverity(%q) err = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseSeverity(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestRunLint lints testdata/lint, whose module has an unresolved import,
+// three unknown-type errors (one repeated by gomib for each of two
+// compliances) and a minor revision finding.
+func TestRunLint(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	ignoreErrors := writeConfig("ignore.json", `{"ignore": ["import-*", "type-unk?own"]}`)
+	demote := writeConfig("demote.json", `{"ignore": ["import-module-*"], "severity": {"type-unknown": "minor"}}`)
+	badSeverity := writeConfig("bad.json", `{"severity": {"type-unknown": "loud"}}`)
+
+	tests := []struct {
+		name  string
+		args  []string
+		exit  int
+		codes map[string]int // findings per code in the JSON output
+	}{
+		{
+			name:  "default fails on error",
+			exit:  1,
+			codes: map[string]int{"import-module-not-found": 1, "type-unknown": 3, "revision-last-updated": 1},
+		},
+		{name: "fail-on fatal", args: []string{"-fail-on", "fatal"}, exit: 0},
+		{name: "fail-on none", args: []string{"-fail-on", "none"}, exit: 0},
+		{name: "fail-on minor", args: []string{"-fail-on", "minor", "-config", ignoreErrors}, exit: 1},
+		{
+			name:  "ignore globs",
+			args:  []string{"-config", ignoreErrors},
+			exit:  0,
+			codes: map[string]int{"revision-last-updated": 1},
+		},
+		{
+			name:  "severity override",
+			args:  []string{"-config", demote},
+			exit:  0,
+			codes: map[string]int{"type-unknown": 3, "revision-last-updated": 1},
+		},
+		{name: "bad override", args: []string{"-config", badSeverity}, exit: 2},
+		{name: "bad fail-on", args: []string{"-fail-on", "loud"}, exit: 2},
+		{name: "bad strictness", args: []string{"-strictness", "lax"}, exit: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"-format", "json", "-p", "testdata/mibs"}, tt.args...)
+			args = append(args, "testdata/lint")
+			var out strings.Builder
+			if got := runLint(args, &out); got != tt.exit {
+				t.Fatalf("exit = %d, want %d\n%s", got, tt.exit, out.String())
+			}
+			if tt.codes == nil {
+				return
+			}
+			var report struct {
+				Diagnostics []lintJSONDiagnostic `json:"diagnostics"`
+			}
+			if err := json.Unmarshal([]byte(out.String()), &report); err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]int)
+			for _, d := range report.Diagnostics {
+				got[d.Code]++
+			}
+			if len(got) != len(tt.codes) {
+				t.Errorf("codes = %v, want %v", got, tt.codes)
+			}
+			for code, n := range tt.codes {
+				if got[code] != n {
+					t.Errorf("%s: %d findings, want %d", code, got[code], n)
+				}
+			}
+		})
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/golangsnmp/gomib"
 	"github.com/golangsnmp/gomib/mib"
 	"github.com/golangsnmp/mibsh/internal/profile"
+	"github.com/golangsnmp/mibsh/internal/snmp"
 )
 
 type pathList []string
@@ -23,24 +25,42 @@ func (p *pathList) Set(v string) error {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLint(os.Args[2:], os.Stdout))
+	}
+
 	var paths pathList
 	var permissive bool
+	var watchMibs bool
 	var target string
 	var community string
 	var version string
+	var oidOrder string
+	var gentle bool
+	var rateLimit float64
+	var maxParallel int
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, `mibsh - interactive SNMP MIB browser and query tool
 
 Usage:
   mibsh [options] [MODULE ...]
+  mibsh lint [options] PATH...    check MIB files (mibsh lint -h)
 
 Options:
-  -p PATH             MIB search path (repeatable, recursive)
+  -p PATH             MIB search path or .zip/.tar.gz/.tgz archive
+                      (repeatable, recursive)
   -permissive         use permissive strictness when loading
+  -watch              reload MIBs when files in the -p paths change
   -target HOST[:PORT] SNMP target for queries
   -community STRING   SNMP community string (default "public")
   -version VERSION    SNMP version: 1, 2c, 3 (default "2c")
+  -oid-order POLICY   walks of agents returning OIDs out of order:
+                      strict stops, lenient continues (default "strict")
+  -gentle             gentle mode for fragile devices: at most 5 requests/s,
+                      no parallel fetches, small GETBULK responses
+  -rate N             limit requests to N per second (default no limit)
+  -parallel N         connections used by parallel table fetches (default 4)
 
 If no -p paths are given, mibsh searches standard system locations:
   - net-snmp: /usr/share/snmp/mibs, ~/.snmp/mibs, $MIBDIRS
@@ -59,16 +79,36 @@ Press ? inside mibsh for key bindings.
 `)
 	}
 
-	flag.Var(&paths, "p", "MIB search path (repeatable)")
+	flag.Var(&paths, "p", "MIB search path or archive (repeatable)")
 	flag.BoolVar(&permissive, "permissive", false, "use permissive strictness")
+	flag.BoolVar(&watchMibs, "watch", false, "reload MIBs when files in the -p paths change")
 	flag.StringVar(&target, "target", "", "SNMP target host[:port]")
 	flag.StringVar(&community, "community", "public", "SNMP community string")
 	flag.StringVar(&version, "version", "2c", "SNMP version (1, 2c, 3)")
+	flag.StringVar(&oidOrder, "oid-order", snmp.OIDOrderStrict, "walk policy for out-of-order OIDs (strict, lenient)")
+	flag.BoolVar(&gentle, "gentle", false, "gentle mode for fragile devices")
+	flag.Float64Var(&rateLimit, "rate", 0, "request rate limit per second (0 for none)")
+	flag.IntVar(&maxParallel, "parallel", 0, "connections used by parallel table fetches")
 	flag.Parse()
 	modules := flag.Args()
 
+	if oidOrder != snmp.OIDOrderStrict && oidOrder != snmp.OIDOrderLenient {
+		fmt.Fprintf(os.Stderr, "error: -oid-order must be %s or %s\n", snmp.OIDOrderStrict, snmp.OIDOrderLenient)
+		os.Exit(2)
+	}
+	if watchMibs && len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "error: -watch needs at least one -p path")
+		os.Exit(2)
+	}
+	if rateLimit < 0 || maxParallel < 0 {
+		fmt.Fprintln(os.Stderr, "error: -rate and -parallel must not be negative")
+		os.Exit(2)
+	}
+
 	fmt.Fprintf(os.Stderr, "Loading MIBs...")
-	m, err := loadMib(paths, modules, permissive)
+	m, err := loadMib(paths, nil, modules, permissive, func(msg string) {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", msg)
+	})
 	fmt.Fprintf(os.Stderr, " done.\n")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
@@ -84,9 +124,19 @@ Press ? inside mibsh for key bindings.
 	}
 
 	cfg := appConfig{
+		mibPaths:   paths,
+		mibModules: modules,
+		permissive: permissive,
+		watchMibs:  watchMibs,
+
 		target:    target,
 		community: community,
 		version:   profile.NormalizeVersion(version),
+		oidOrder:  oidOrder,
+
+		gentle:      gentle,
+		rateLimit:   rateLimit,
+		maxParallel: maxParallel,
 	}
 
 	profiles := profile.NewStore()
@@ -111,38 +161,75 @@ Press ? inside mibsh for key bindings.
 	}
 }
 
-func loadMib(paths []string, modules []string, permissive bool) (*mib.Mib, error) {
+// loadMib loads modules from paths, or from the system MIB locations when
+// paths is empty. Extra sources loaded at runtime are searched first, so a
+// module they provide replaces one of the same name. Paths that cannot be
+// read are passed to warn and skipped.
+func loadMib(paths, extra, modules []string, permissive bool, warn func(string)) (*mib.Mib, error) {
 	var opts []gomib.LoadOption
 
-	if len(paths) > 0 {
-		var sources []gomib.Source
-		for _, p := range paths {
-			src, err := gomib.Dir(p)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "warning: cannot access path %s: %v\n", p, err)
-				continue
-			}
-			sources = append(sources, src)
-		}
-		if len(sources) == 0 {
-			return nil, fmt.Errorf("no valid MIB sources from provided paths")
+	var extraSources []gomib.Source
+	for _, p := range extra {
+		src, err := extraMibSource(p)
+		if err != nil {
+			warn(fmt.Sprintf("cannot access path %s: %v", p, err))
+			continue
 		}
-		if len(sources) == 1 {
-			opts = append(opts, gomib.WithSource(sources[0]))
-		} else {
-			opts = append(opts, gomib.WithSource(gomib.Multi(sources...)))
-		}
-	} else {
-		opts = append(opts, gomib.WithSystemPaths())
+		extraSources = append(extraSources, src)
+	}
+	opts = append(opts, gomib.WithSource(extraSources...))
+
+	pathOpt, err := searchPathOption(paths, warn)
+	if err != nil {
+		return nil, err
 	}
+	opts = append(opts, pathOpt)
 
 	if permissive {
 		opts = append(opts, gomib.WithResolverStrictness(mib.ResolverPermissive))
 	}
 
 	if len(modules) > 0 {
-		opts = append(opts, gomib.WithModules(modules...))
+		// Named modules restrict the load, so name every module the extra
+		// sources hold too or they would be skipped.
+		names := slices.Clone(modules)
+		for _, src := range extraSources {
+			if list, err := src.ListModules(); err == nil {
+				names = append(names, list...)
+			}
+		}
+		opts = append(opts, gomib.WithModules(names...))
 	}
 
 	return gomib.Load(context.Background(), opts...)
 }
+
+// searchPathOption returns the load option for the -p paths, directories
+// or archives, or for the system MIB locations when paths is empty.
+func searchPathOption(paths []string, warn func(string)) (gomib.LoadOption, error) {
+	if len(paths) == 0 {
+		return gomib.WithSystemPaths(), nil
+	}
+	var sources []gomib.Source
+	for _, p := range paths {
+		var src gomib.Source
+		var err error
+		if isMibArchive(p) {
+			src, err = newArchiveSource(p)
+		} else {
+			src, err = gomib.Dir(p)
+		}
+		if err != nil {
+			warn(fmt.Sprintf("cannot access path %s: %v", p, err))
+			continue
+		}
+		sources = append(sources, src)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no valid MIB sources from provided paths")
+	}
+	if len(sources) == 1 {
+		return gomib.WithSource(sources[0]), nil
+	}
+	return gomib.WithSource(gomib.Multi(sources...)), nil
+}
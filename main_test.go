@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// loadTestMib loads the modules under testdata/mibs.
+func loadTestMib(t *testing.T) *mib.Mib {
+	t.Helper()
+	m, err := loadMib([]string{"testdata/mibs"}, nil, nil, false, func(msg string) {
+		t.Log(msg)
+	})
+	if err != nil {
+		t.Fatalf("load test MIBs: %v", err)
+	}
+	return m
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/golangsnmp/gomib"
+)
+
+// maxArchiveMibSize caps how much of one archive member is read. MIB files
+// are far smaller; anything bigger is not one, or is a decompression bomb.
+const maxArchiveMibSize = 16 << 20
+
+// isMibArchive reports whether path names an archive mibsh can read MIBs
+// from, by extension.
+func isMibArchive(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".zip") || strings.HasSuffix(lower, ".tar.gz") ||
+		strings.HasSuffix(lower, ".tgz")
+}
+
+// archiveSource is a gomib.Source over the MIB files in a zip or gzipped
+// tar archive, read into memory once. Members are found at any depth, like
+// gomib.DirTree, and reported as paths under the archive's own path.
+type archiveSource struct {
+	files map[string]gomib.FindResult // module name -> content and path
+}
+
+func newArchiveSource(archive string) (gomib.Source, error) {
+	s := &archiveSource{files: make(map[string]gomib.FindResult)}
+	var err error
+	if strings.HasSuffix(strings.ToLower(archive), ".zip") {
+		err = s.readZip(archive)
+	} else {
+		err = s.readTarGz(archive)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", archive, err)
+	}
+	return s, nil
+}
+
+func (s *archiveSource) readZip(archive string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !s.wants(f.Name, f.UncompressedSize64) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = s.add(archive, f.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *archiveSource) readTarGz(archive string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg || !s.wants(hdr.Name, uint64(max(hdr.Size, 0))) {
+			continue
+		}
+		if err := s.add(archive, hdr.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// wants reports whether the member at name is a MIB file not yet indexed.
+// Hidden entries and macOS resource forks are skipped.
+func (s *archiveSource) wants(name string, size uint64) bool {
+	if size > maxArchiveMibSize {
+		return false
+	}
+	for _, part := range strings.Split(path.Clean(name), "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return false
+		}
+	}
+	base := path.Base(name)
+	ext := strings.ToLower(path.Ext(base))
+	if !slices.Contains(gomib.DefaultExtensions(), ext) {
+		return false
+	}
+	_, seen := s.files[strings.TrimSuffix(base, path.Ext(base))]
+	return !seen // first match wins, as in gomib.DirTree
+}
+
+func (s *archiveSource) add(archive, name string, r io.Reader) error {
+	content, err := io.ReadAll(io.LimitReader(r, maxArchiveMibSize))
+	if err != nil {
+		return err
+	}
+	base := path.Base(name)
+	s.files[strings.TrimSuffix(base, path.Ext(base))] = gomib.FindResult{
+		Content: content,
+		Path:    filepath.Join(archive, filepath.FromSlash(path.Clean(name))),
+	}
+	return nil
+}
+
+func (s *archiveSource) Find(name string) (gomib.FindResult, error) {
+	res, ok := s.files[name]
+	if !ok {
+		return gomib.FindResult{}, fs.ErrNotExist
+	}
+	return res, nil
+}
+
+func (s *archiveSource) ListModules() ([]string, error) {
+	return slices.Sorted(maps.Keys(s.files)), nil
+}
+
+// readMibSource reads the MIB file a module was loaded from. A path under
+// an archive (see archiveSource) is read from the archive member.
+func readMibSource(sourcePath string) ([]byte, error) {
+	content, err := os.ReadFile(sourcePath)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, syscall.ENOTDIR) {
+		return content, err
+	}
+	for archive := filepath.Dir(sourcePath); archive != filepath.Dir(archive); archive = filepath.Dir(archive) {
+		if !isMibArchive(archive) {
+			continue
+		}
+		src, aerr := newArchiveSource(archive)
+		if aerr != nil {
+			return nil, aerr
+		}
+		for _, res := range src.(*archiveSource).files {
+			if res.Path == sourcePath {
+				return res.Content, nil
+			}
+		}
+		break
+	}
+	return nil, err
+}
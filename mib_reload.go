@@ -0,0 +1,200 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/golangsnmp/gomib"
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// mibWatchInterval is how often the MIB paths are checked for changes.
+const mibWatchInterval = 2 * time.Second
+
+// mibReloadMsg carries a freshly loaded MIB set.
+type mibReloadMsg struct {
+	mib      *mib.Mib
+	warnings []string
+	err      error
+	added    string // source loaded for the first time, empty for a reload
+}
+
+// mibWatchTickMsg carries the state of the watched paths at a tick.
+type mibWatchTickMsg struct {
+	seq   int
+	stamp string
+}
+
+// reloadMibCmd loads MIBs again with the startup paths, modules and
+// strictness plus the extra sources, off the UI goroutine. added is passed
+// through to the result when extra ends with a newly added source.
+func reloadMibCmd(cfg appConfig, extra []string, added string) tea.Cmd {
+	return func() tea.Msg {
+		var warnings []string
+		m, err := loadMib(cfg.mibPaths, extra, cfg.mibModules, cfg.permissive, func(msg string) {
+			warnings = append(warnings, msg)
+		})
+		// Adding a source is how its problems get seen, so severe
+		// diagnostics go in the report instead of refusing the load.
+		if added != "" && m != nil && errors.Is(err, gomib.ErrDiagnosticThreshold) &&
+			!errors.Is(err, gomib.ErrMissingModules) {
+			err = nil
+		}
+		return mibReloadMsg{mib: m, warnings: warnings, err: err, added: added}
+	}
+}
+
+// mibWatcher polls the MIB paths for added, removed and modified files.
+// Polling keeps it portable and needs nothing beyond the standard library.
+type mibWatcher struct {
+	active bool
+	seq    int    // incremented on start/stop to discard stale ticks
+	stamp  string // sizes and modification times seen at the last check
+}
+
+func (w *mibWatcher) start(paths []string) tea.Cmd {
+	w.active = true
+	w.seq++
+	w.stamp = mibPathsStamp(paths)
+	return w.tickCmd(paths)
+}
+
+func (w *mibWatcher) stop() {
+	w.active = false
+	w.seq++
+}
+
+func (w *mibWatcher) tickCmd(paths []string) tea.Cmd {
+	seq := w.seq
+	return tea.Tick(mibWatchInterval, func(time.Time) tea.Msg {
+		return mibWatchTickMsg{seq: seq, stamp: mibPathsStamp(paths)}
+	})
+}
+
+// mibPathsStamp summarises every file under paths by name, size and
+// modification time. Any edit, addition or removal changes the result.
+func mibPathsStamp(paths []string) string {
+	var b strings.Builder
+	for _, root := range paths {
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			fmt.Fprintf(&b, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+	}
+	return b.String()
+}
+
+// relocateNode finds the counterpart of node in m: by module-qualified
+// name, then by plain name. Returns nil if the name no longer exists.
+func relocateNode(node *mib.Node, m *mib.Mib) *mib.Node {
+	name := node.Name()
+	if name == "" {
+		return nil
+	}
+	if mod := node.Module(); mod != nil {
+		if n := m.Resolve(mod.Name() + "::" + name); n != nil {
+			return n
+		}
+	}
+	return m.Node(name)
+}
+
+// buildMibLoadReport lists the modules a newly loaded source provided and
+// the diagnostics reported against them. prev holds the module names that
+// were loaded before, to tell new modules from replaced ones.
+func buildMibLoadReport(path string, mods []*mib.Module, prev map[string]bool, diags []mib.Diagnostic) []reportLine {
+	var b reportBuilder
+	b.add(reportPlain, "Source  %s", path)
+	b.blank()
+
+	names := make(map[string]bool, len(mods))
+	replaced := 0
+	for _, mod := range mods {
+		names[mod.Name()] = true
+		if prev[mod.Name()] {
+			replaced++
+		}
+	}
+	b.add(reportHeading, "%d modules (%d new, %d replaced)", len(mods), len(mods)-replaced, replaced)
+	for _, mod := range mods {
+		state := "new"
+		if prev[mod.Name()] {
+			state = "replaced"
+		}
+		b.add(reportPlain, "  %-32s %-9s %s", mod.Name(), state, mod.SourcePath())
+	}
+
+	var found []mib.Diagnostic
+	for _, d := range diags {
+		if names[d.Module] {
+			found = append(found, d)
+		}
+	}
+	b.blank()
+	if len(found) == 0 {
+		b.add(reportGood, "No diagnostics.")
+		return b.lines
+	}
+	slices.SortStableFunc(found, func(a, b mib.Diagnostic) int {
+		return cmp.Compare(a.Severity, b.Severity)
+	})
+	b.add(reportHeading, "%d diagnostics", len(found))
+	for _, d := range found {
+		kind := reportMuted
+		switch {
+		case d.Severity <= mib.SeverityError:
+			kind = reportBad
+		case d.Severity <= mib.SeverityMinor:
+			kind = reportWarn
+		}
+		b.add(kind, "  %-9s %s", "["+d.Severity.String()+"]", diagText(d))
+	}
+	return b.lines
+}
+
+// mibLoadReportFile is the default file name for a saved load report.
+func mibLoadReportFile(path string) string {
+	base := filepath.Base(path)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	return "load-" + strings.TrimSuffix(base, ".tar") + ".txt"
+}
+
+// mibEditMsg reports that the external editor opened on a MIB file exited.
+type mibEditMsg struct {
+	path   string
+	module string
+	stamp  string // mibPathsStamp of path before editing
+	err    error
+}
+
+// editorCommand builds the command that opens path in the user's editor
+// ($VISUAL, then $EDITOR, then vi) at line, when line is known. The
+// variable may carry arguments, as in "code -w".
+func editorCommand(path string, line int) *exec.Cmd {
+	editor := cmp.Or(os.Getenv("VISUAL"), os.Getenv("EDITOR"), "vi")
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	if line > 0 {
+		args = append(args, fmt.Sprintf("+%d", line))
+	}
+	args = append(args, path)
+	return exec.Command(args[0], args[1:]...)
+}
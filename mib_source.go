@@ -0,0 +1,117 @@
+package main
+
+import (
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib"
+)
+
+// extraMibSource opens a MIB source loaded at runtime. A directory or
+// archive is indexed recursively, since vendor bundles are usually nested;
+// a plain file is served on its own.
+func extraMibSource(path string) (gomib.Source, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var src gomib.Source
+	switch {
+	case info.IsDir():
+		src, err = gomib.DirTree(path)
+	case isMibArchive(path):
+		src, err = newArchiveSource(path)
+	default:
+		src = fileSource{path: path}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newDeclaredSource(src)
+}
+
+// fileSource is a gomib.Source holding one MIB file, listed under the
+// file's base name.
+type fileSource struct {
+	path string
+}
+
+func (s fileSource) name() string {
+	base := filepath.Base(s.path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+func (s fileSource) Find(name string) (gomib.FindResult, error) {
+	if name != s.name() {
+		return gomib.FindResult{}, fs.ErrNotExist
+	}
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return gomib.FindResult{Path: s.path}, err
+	}
+	return gomib.FindResult{Content: content, Path: s.path}, nil
+}
+
+func (s fileSource) ListModules() ([]string, error) {
+	return []string{s.name()}, nil
+}
+
+// mibHeaderRe matches the "NAME DEFINITIONS ::=" line that opens a module.
+var mibHeaderRe = regexp.MustCompile(`(?m)^\s*([A-Za-z][A-Za-z0-9-]*)\s+DEFINITIONS\s*::=`)
+
+// declaredSource lists the modules of another source by the names declared
+// in their files rather than the file names. Vendor files are often named
+// loosely ("acme-v2.my" for ACME-MIB), and loading by module name only
+// finds them this way.
+type declaredSource struct {
+	src   gomib.Source
+	index map[string]string // declared module name -> name listed by src
+}
+
+func newDeclaredSource(src gomib.Source) (gomib.Source, error) {
+	listed, err := src.ListModules()
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]string, len(listed))
+	for _, name := range listed {
+		res, err := src.Find(name)
+		if err != nil {
+			continue
+		}
+		found := false
+		for _, match := range mibHeaderRe.FindAllSubmatch(res.Content, -1) {
+			found = true
+			if _, ok := index[string(match[1])]; !ok {
+				index[string(match[1])] = name
+			}
+		}
+		if !found {
+			index[name] = name // let the loader report what is wrong with it
+		}
+	}
+	return &declaredSource{src: src, index: index}, nil
+}
+
+func (s *declaredSource) Find(name string) (gomib.FindResult, error) {
+	listed, ok := s.index[name]
+	if !ok {
+		return gomib.FindResult{}, fs.ErrNotExist
+	}
+	return s.src.Find(listed)
+}
+
+func (s *declaredSource) ListModules() ([]string, error) {
+	return slices.Sorted(maps.Keys(s.index)), nil
+}
+
+// withinSource reports whether a module's source path came from the source
+// opened at root.
+func withinSource(sourcePath, root string) bool {
+	return sourcePath == root || strings.HasPrefix(sourcePath, root+string(filepath.Separator))
+}
@@ -81,6 +81,14 @@ func (mm *moduleModel) toggleExpand() {
 	}
 }
 
+// selectedModule returns the module under the cursor, or nil.
+func (mm *moduleModel) selectedModule() *mib.Module {
+	if i := mm.selectedItem(); i >= 0 && i < len(mm.filtered) {
+		return mm.filtered[i]
+	}
+	return nil
+}
+
 // rebuild regenerates the flattened view lines from the current filtered list.
 func (mm *moduleModel) rebuild() {
 	mm.rebuildViewLines(len(mm.filtered),
@@ -98,7 +106,7 @@ func (mm *moduleModel) view() string {
 	b.WriteByte('\n')
 
 	// Line 2: status
-	status := fmt.Sprintf("modules: %d/%d  [enter] expand", len(mm.filtered), len(mm.all))
+	status := fmt.Sprintf("modules: %d/%d  [enter] expand  [ctrl+e] edit", len(mm.filtered), len(mm.all))
 	b.WriteString(styles.StatusText.Render(status))
 	b.WriteByte('\n')
 
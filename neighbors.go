@@ -0,0 +1,468 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/profile"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+const neighborHeaderLines = 4 // header + path + column headers + separator
+
+// LLDP-MIB local port and remote system columns. Remote rows are indexed
+// by lldpRemTimeMark, lldpRemLocalPortNum and lldpRemIndex; management
+// addresses add the address subtype and the length-prefixed address.
+const (
+	oidLldpLocPortId   = "1.0.8802.1.1.2.1.3.7.1.3"
+	oidLldpLocPortDesc = "1.0.8802.1.1.2.1.3.7.1.4"
+
+	oidLldpRemChassisIdSubtype = "1.0.8802.1.1.2.1.4.1.1.4"
+	oidLldpRemChassisId        = "1.0.8802.1.1.2.1.4.1.1.5"
+	oidLldpRemPortIdSubtype    = "1.0.8802.1.1.2.1.4.1.1.6"
+	oidLldpRemPortId           = "1.0.8802.1.1.2.1.4.1.1.7"
+	oidLldpRemPortDesc         = "1.0.8802.1.1.2.1.4.1.1.8"
+	oidLldpRemSysName          = "1.0.8802.1.1.2.1.4.1.1.9"
+	oidLldpRemSysDesc          = "1.0.8802.1.1.2.1.4.1.1.10"
+	oidLldpRemManAddrIfSubtype = "1.0.8802.1.1.2.1.4.2.1.3"
+)
+
+// CISCO-CDP-MIB cache columns, indexed by ifIndex and cdpCacheDeviceIndex.
+const (
+	cdpModule = "CISCO-CDP-MIB"
+
+	oidCdpCacheAddressType = "1.3.6.1.4.1.9.9.23.1.2.1.1.3"
+	oidCdpCacheAddress     = "1.3.6.1.4.1.9.9.23.1.2.1.1.4"
+	oidCdpCacheDeviceId    = "1.3.6.1.4.1.9.9.23.1.2.1.1.6"
+	oidCdpCacheDevicePort  = "1.3.6.1.4.1.9.9.23.1.2.1.1.7"
+	oidCdpCachePlatform    = "1.3.6.1.4.1.9.9.23.1.2.1.1.8"
+)
+
+// neighborOIDs returns the columns a neighbor collection walks, with the
+// CDP cache when cdp is set.
+func neighborOIDs(cdp bool) []string {
+	oids := []string{
+		oidLldpLocPortId, oidLldpLocPortDesc,
+		oidLldpRemChassisIdSubtype, oidLldpRemChassisId, oidLldpRemPortIdSubtype,
+		oidLldpRemPortId, oidLldpRemPortDesc, oidLldpRemSysName, oidLldpRemSysDesc,
+		oidLldpRemManAddrIfSubtype,
+	}
+	if cdp {
+		oids = append(oids,
+			oidCdpCacheAddressType, oidCdpCacheAddress, oidCdpCacheDeviceId,
+			oidCdpCacheDevicePort, oidCdpCachePlatform, oidIfName, oidIfDescr,
+		)
+	}
+	return oids
+}
+
+// cdpLoaded reports whether CISCO-CDP-MIB is loaded, which is what turns
+// on reading the CDP cache.
+func cdpLoaded(m *mib.Mib) bool {
+	return m != nil && m.Module(cdpModule) != nil
+}
+
+// neighbor is one remote system seen by LLDP or CDP.
+type neighbor struct {
+	proto      string // "LLDP" or "CDP"
+	port       int64  // lldpRemLocalPortNum or ifIndex, for ordering
+	localPort  string
+	name       string // sysName, else chassis or device ID
+	remotePort string
+	addr       netip.Addr // management address, invalid if none advertised
+	platform   string
+}
+
+// neighborModel lists the connected device's neighbors and the path of
+// hops taken to reach it.
+type neighborModel struct {
+	target    string
+	loading   bool
+	follow    bool // collect once the connect-time summary is in
+	label     string
+	neighbors []neighbor
+	lldp      int
+	cdp       bool // CDP cache was read
+	errText   string
+
+	trail   []profile.Device // devices visited by hops, first to current
+	pending string           // target of a hop being connected
+
+	lv    ListView[neighbor]
+	width int
+}
+
+func newNeighborModel() neighborModel {
+	return neighborModel{lv: NewListView[neighbor](neighborHeaderLines)}
+}
+
+// start clears the list for a new collection from target, which reads the
+// CDP cache as well when cdp is set.
+func (v *neighborModel) start(target string, cdp bool) {
+	v.target = target
+	v.loading = true
+	v.follow = false
+	v.label = "neighbors " + target
+	v.neighbors = nil
+	v.lldp = 0
+	v.cdp = cdp
+	v.errText = ""
+	v.lv.SetRows(nil)
+}
+
+// arrive records a new connection. A connection made by a hop extends the
+// path, or cuts it back when it returns to a device already on it; any
+// other connection starts a new path. Reports whether it was a hop.
+func (v *neighborModel) arrive(dev profile.Device) bool {
+	hop := v.pending != "" && v.pending == dev.Target
+	v.pending = ""
+	if !hop || len(v.trail) == 0 {
+		v.trail = []profile.Device{dev}
+		return hop
+	}
+	if i := slices.IndexFunc(v.trail, func(d profile.Device) bool { return d.Target == dev.Target }); i >= 0 {
+		v.trail = v.trail[:i+1]
+		v.trail[i] = dev
+		return true
+	}
+	v.trail = append(v.trail, dev)
+	return true
+}
+
+// previous returns the device before the current one on the path.
+func (v *neighborModel) previous() (profile.Device, bool) {
+	if len(v.trail) < 2 {
+		return profile.Device{}, false
+	}
+	return v.trail[len(v.trail)-2], true
+}
+
+// load builds the neighbor list from collected columns.
+func (v *neighborModel) load(pdus map[string][]gosnmp.SnmpPDU, err error, m *mib.Mib) {
+	v.loading = false
+	v.errText = ""
+	if err != nil {
+		v.errText = snmp.ErrorText(err, m)
+	}
+
+	col := func(oid string) map[string]gosnmp.SnmpPDU { return summaryColumn(pdus, oid) }
+	var ns []neighbor
+	ns = append(ns, lldpNeighbors(col, m)...)
+	v.lldp = len(ns)
+	if v.cdp {
+		ns = append(ns, cdpNeighbors(col, m)...)
+	}
+	slices.SortStableFunc(ns, func(a, b neighbor) int {
+		return cmp.Compare(a.port, b.port)
+	})
+	v.neighbors = ns
+	v.lv.SetRows(ns)
+	v.lv.GoTop()
+}
+
+// lldpNeighbors reads lldpRemTable, naming local ports from
+// lldpLocPortTable and joining lldpRemManAddrTable.
+func lldpNeighbors(col func(string) map[string]gosnmp.SnmpPDU, m *mib.Mib) []neighbor {
+	locID, locDesc := col(oidLldpLocPortId), col(oidLldpLocPortDesc)
+	chassisType, chassis := col(oidLldpRemChassisIdSubtype), col(oidLldpRemChassisId)
+	portType, port, portDesc := col(oidLldpRemPortIdSubtype), col(oidLldpRemPortId), col(oidLldpRemPortDesc)
+	sysName, sysDesc := col(oidLldpRemSysName), col(oidLldpRemSysDesc)
+
+	// Management addresses by local port and remote index, IPv4 preferred.
+	addrs := make(map[string]netip.Addr)
+	for suffix := range col(oidLldpRemManAddrIfSubtype) {
+		arcs := strings.Split(suffix, ".")
+		if len(arcs) < 5 {
+			continue
+		}
+		addr, ok := indexAddr(arcs[3], arcs[4:])
+		if !ok {
+			continue
+		}
+		key := arcs[1] + "." + arcs[2]
+		if prev, ok := addrs[key]; !ok || (addr.Is4() && !prev.Is4()) {
+			addrs[key] = addr
+		}
+	}
+
+	var ns []neighbor
+	for _, suffix := range sortedSuffixes(chassis) {
+		arcs := strings.Split(suffix, ".")
+		if len(arcs) != 3 {
+			continue
+		}
+		portNum, _ := strconv.ParseInt(arcs[1], 10, 64)
+		n := neighbor{
+			proto:      "LLDP",
+			port:       portNum,
+			localPort:  pduText(locDesc[arcs[1]], m),
+			name:       pduText(sysName[suffix], m),
+			remotePort: lldpID(portType[suffix], port[suffix], 3, 4, m),
+			addr:       addrs[arcs[1]+"."+arcs[2]],
+			platform:   firstLine(pduText(sysDesc[suffix], m)),
+		}
+		if n.localPort == "" {
+			n.localPort = pduText(locID[arcs[1]], m)
+		}
+		if n.localPort == "" {
+			n.localPort = arcs[1]
+		}
+		if n.name == "" {
+			n.name = lldpID(chassisType[suffix], chassis[suffix], 4, 5, m)
+		}
+		// MAC and locally assigned port IDs say little; the description
+		// usually names the port.
+		if st, _ := snmp.IntegerValue(portType[suffix]); st == 3 || st == 7 {
+			if d := pduText(portDesc[suffix], m); d != "" {
+				n.remotePort = d
+			}
+		}
+		ns = append(ns, n)
+	}
+	return ns
+}
+
+// cdpNeighbors reads cdpCacheTable, naming local ports from ifName or
+// ifDescr.
+func cdpNeighbors(col func(string) map[string]gosnmp.SnmpPDU, m *mib.Mib) []neighbor {
+	addrType, address := col(oidCdpCacheAddressType), col(oidCdpCacheAddress)
+	devID, devPort, platform := col(oidCdpCacheDeviceId), col(oidCdpCacheDevicePort), col(oidCdpCachePlatform)
+	ifName, ifDescr := col(oidIfName), col(oidIfDescr)
+
+	var ns []neighbor
+	for _, suffix := range sortedSuffixes(devID) {
+		ifIndex, _, ok := strings.Cut(suffix, ".")
+		if !ok {
+			continue
+		}
+		portNum, _ := strconv.ParseInt(ifIndex, 10, 64)
+		n := neighbor{
+			proto:      "CDP",
+			port:       portNum,
+			localPort:  pduText(ifName[ifIndex], m),
+			name:       pduText(devID[suffix], m),
+			remotePort: pduText(devPort[suffix], m),
+			platform:   pduText(platform[suffix], m),
+		}
+		if n.localPort == "" {
+			n.localPort = pduText(ifDescr[ifIndex], m)
+		}
+		if n.localPort == "" {
+			n.localPort = "if" + ifIndex
+		}
+		// CiscoNetworkProtocol ip(1) and ipv6(20)
+		if t, _ := snmp.IntegerValue(addrType[suffix]); t == 1 || t == 20 {
+			if b, ok := address[suffix].Value.([]byte); ok {
+				if addr, ok := netip.AddrFromSlice(b); ok {
+					n.addr = addr.Unmap()
+				}
+			}
+		}
+		ns = append(ns, n)
+	}
+	return ns
+}
+
+// indexAddr decodes an AddressFamilyNumbers subtype and a length-prefixed
+// address from index arcs. Only ipV4(1) and ipV6(2) are understood.
+func indexAddr(subtype string, arcs []string) (netip.Addr, bool) {
+	if subtype != "1" && subtype != "2" {
+		return netip.Addr{}, false
+	}
+	n, err := strconv.Atoi(arcs[0])
+	if err != nil || n != len(arcs)-1 {
+		return netip.Addr{}, false
+	}
+	b := make([]byte, n)
+	for i, a := range arcs[1:] {
+		v, err := strconv.ParseUint(a, 10, 8)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		b[i] = byte(v)
+	}
+	addr, ok := netip.AddrFromSlice(b)
+	return addr, ok
+}
+
+// lldpID renders a chassis or port ID. The subtype names which values are
+// MAC addresses and which network addresses, whose first octet is the
+// address family.
+func lldpID(subtype, id gosnmp.SnmpPDU, macSubtype, addrSubtype int64, m *mib.Mib) string {
+	b, ok := id.Value.([]byte)
+	if !ok {
+		return pduText(id, m)
+	}
+	switch st, _ := snmp.IntegerValue(subtype); st {
+	case macSubtype:
+		if len(b) == 6 {
+			return fmt.Sprintf("%02X:%02X:%02X:%02X:%02X:%02X", b[0], b[1], b[2], b[3], b[4], b[5])
+		}
+	case addrSubtype:
+		if len(b) > 1 {
+			if addr, ok := netip.AddrFromSlice(b[1:]); ok {
+				return addr.String()
+			}
+		}
+	}
+	return pduText(id, m)
+}
+
+// pduText formats a column value, or returns "" if the agent left it out.
+func pduText(pdu gosnmp.SnmpPDU, m *mib.Mib) string {
+	if pdu.Name == "" {
+		return ""
+	}
+	return strings.TrimSpace(snmp.FormatPDUToResult(pdu, m).Value)
+}
+
+// selected returns the neighbor under the cursor, or nil.
+func (v *neighborModel) selected() *neighbor {
+	return v.lv.Selected()
+}
+
+func (v *neighborModel) setSize(width, height int) {
+	v.width = width
+	v.lv.SetSize(width, height)
+}
+
+func (v *neighborModel) clickRow(row int) {
+	if row >= 0 && row < v.lv.Len() {
+		v.lv.SetCursor(row)
+	}
+}
+
+// pathView renders the hops taken, oldest first, dropping the oldest
+// when they do not fit.
+func (v *neighborModel) pathView() string {
+	const sep = " › "
+	names := make([]string, len(v.trail))
+	for i, d := range v.trail {
+		names[i] = d.Name
+		if names[i] == "" {
+			names[i] = d.Target
+		}
+	}
+	prefix := "PATH "
+	for len(names) > 1 && lipgloss.Width(prefix+strings.Join(names, sep)) > v.width {
+		names = names[1:]
+		prefix = "PATH …" + sep
+	}
+	if len(names) == 0 {
+		return styles.Label.Render(prefix)
+	}
+	last := len(names) - 1
+	before := prefix
+	if last > 0 {
+		before += strings.Join(names[:last], sep) + sep
+	}
+	current := truncate(names[last], v.width-lipgloss.Width(before))
+	return styles.Label.Render(before) + styles.Value.Render(current)
+}
+
+// view renders the neighbors pane content.
+func (v *neighborModel) view() string {
+	if v.target == "" {
+		return styles.EmptyText.Render("(no neighbors read)")
+	}
+
+	var b strings.Builder
+
+	header := fmt.Sprintf("NEIGHBORS %s", v.target)
+	if v.loading {
+		header += " " + IconLoading
+	} else {
+		header += fmt.Sprintf(" | %d LLDP", v.lldp)
+		if v.cdp {
+			header += fmt.Sprintf(" | %d CDP", len(v.neighbors)-v.lldp)
+		}
+	}
+	b.WriteString(styles.Header.Info.Render(header))
+	if v.errText != "" {
+		b.WriteString("  " + styles.Status.WarnMsg.Render("incomplete: "+v.errText))
+	}
+	b.WriteByte('\n')
+	b.WriteString(v.pathView())
+	b.WriteByte('\n')
+
+	if len(v.neighbors) == 0 {
+		switch {
+		case v.loading:
+			b.WriteString(styles.EmptyText.Render(IconLoading + " Reading neighbor tables..."))
+		default:
+			b.WriteString(styles.EmptyText.Render("(the agent reported no neighbors)"))
+		}
+		return b.String()
+	}
+
+	portW, nameW, remW, addrW, protoW := 10, 16, 11, 12, 5
+	for _, n := range v.neighbors {
+		portW = max(portW, len(n.localPort))
+		nameW = max(nameW, len(n.name))
+		remW = max(remW, len(n.remotePort))
+		if n.addr.IsValid() {
+			addrW = max(addrW, len(n.addr.String()))
+		}
+	}
+	portW = min(portW, 24)
+	nameW = min(nameW, 32)
+	remW = min(remW, 24)
+	addrW = min(addrW, 39)
+	platW := max(10, v.width-portW-nameW-remW-addrW-protoW-12)
+
+	hdr := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s  %-*s  %s",
+		portW, "LOCAL PORT", nameW, "NEIGHBOR", remW, "REMOTE PORT", addrW, "MGMT ADDRESS", protoW, "PROTO", "PLATFORM")
+	b.WriteString(styles.Header.Info.Render(truncate(hdr, v.width)))
+	b.WriteByte('\n')
+
+	sepW := portW + nameW + remW + addrW + protoW + platW + 12
+	b.WriteString("  " + styles.Table.Sep.Render(strings.Repeat("─", max(0, min(sepW, v.width-2)))))
+	b.WriteByte('\n')
+
+	vis := v.lv.VisibleRows()
+	offset := v.lv.Offset()
+	cursor := v.lv.Cursor()
+	end := min(offset+vis, len(v.neighbors))
+
+	for i := offset; i < end; i++ {
+		n := v.neighbors[i]
+
+		var line strings.Builder
+		if i == cursor {
+			line.WriteString(selectedBorder() + " ")
+		} else {
+			line.WriteString("  ")
+		}
+		addr := "-"
+		addrStyle := styles.Label
+		if n.addr.IsValid() {
+			addr = n.addr.String()
+			addrStyle = styles.Value
+		}
+		line.WriteString(styles.Value.Render(fmt.Sprintf("%-*s", portW, truncate(n.localPort, portW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Value.Render(fmt.Sprintf("%-*s", nameW, truncate(n.name, nameW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Value.Render(fmt.Sprintf("%-*s", remW, truncate(n.remotePort, remW))))
+		line.WriteString("  ")
+		line.WriteString(addrStyle.Render(fmt.Sprintf("%-*s", addrW, truncate(addr, addrW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Label.Render(fmt.Sprintf("%-*s", protoW, n.proto)))
+		line.WriteString("  ")
+		line.WriteString(styles.Label.Render(truncate(n.platform, platW)))
+
+		b.WriteString(line.String())
+		if i < end-1 {
+			b.WriteByte('\n')
+		}
+	}
+
+	return attachScrollbar(b.String(), vis, len(v.neighbors), vis, offset)
+}
@@ -14,15 +14,17 @@ const (
 	overlayHelp
 	overlayFilterHelp
 	overlayConnect
+	overlayRowEdit
 )
 
-// overlayModel manages modal overlays (help dialog, connect dialog).
+// overlayModel manages modal overlays (help, connect, and row editor dialogs).
 type overlayModel struct {
 	kind overlayKind
 }
 
 func (o *overlayModel) isDialog() bool {
-	return o.kind == overlayHelp || o.kind == overlayFilterHelp || o.kind == overlayConnect
+	return o.kind == overlayHelp || o.kind == overlayFilterHelp ||
+		o.kind == overlayConnect || o.kind == overlayRowEdit
 }
 
 // drawCentered draws content in a centered dialog box on the canvas.
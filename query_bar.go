@@ -1,6 +1,9 @@
 package main
 
 import (
+	"cmp"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -16,12 +19,14 @@ const (
 	queryGet queryOp = iota
 	queryGetNext
 	queryWalk
+	queryLoad
 )
 
 // queryCmd represents a parsed query bar command.
 type queryCmd struct {
-	op  queryOp
-	oid string // resolved dotted OID string
+	op   queryOp
+	oid  string // resolved dotted OID string
+	path string // MIB file or directory for queryLoad
 }
 
 // queryBarModel is the bottom-bar command input for direct SNMP queries.
@@ -36,7 +41,7 @@ type queryBarModel struct {
 
 func newQueryBar(m *mib.Mib) queryBarModel {
 	ti := newStyledInput(": ", 256)
-	ti.Placeholder = "get|walk|next NAME or OID (tab to complete)"
+	ti.Placeholder = "get|walk|next NAME or OID, load PATH (tab to complete)"
 	s := ti.Styles()
 	s.Cursor = textinput.CursorStyle{
 		Color: palette.Primary,
@@ -45,7 +50,15 @@ func newQueryBar(m *mib.Mib) queryBarModel {
 	}
 	ti.SetStyles(s)
 
-	// Build sorted name list for tab completion
+	return queryBarModel{
+		input: ti,
+		mib:   m,
+		names: completionNames(m),
+	}
+}
+
+// completionNames returns the sorted node names used for tab completion.
+func completionNames(m *mib.Mib) []string {
 	var names []string
 	for node := range m.Nodes() {
 		name := node.Name()
@@ -54,12 +67,7 @@ func newQueryBar(m *mib.Mib) queryBarModel {
 		}
 	}
 	slices.Sort(names)
-
-	return queryBarModel{
-		input: ti,
-		mib:   m,
-		names: names,
-	}
+	return names
 }
 
 func (q *queryBarModel) activate() tea.Cmd {
@@ -102,6 +110,14 @@ func (q *queryBarModel) parse() *queryCmd {
 		case "walk":
 			op = queryWalk
 			arg = strings.TrimSpace(parts[1])
+		case "load":
+			path := expandHome(strings.TrimSpace(parts[1]))
+			if path == "" {
+				q.err = "missing path"
+				return nil
+			}
+			q.err = ""
+			return &queryCmd{op: queryLoad, path: path}
 		default:
 			// Not a recognized command, treat entire text as the target
 		}
@@ -145,6 +161,12 @@ func (q *queryBarModel) tabComplete() {
 		case "get", "next", "getnext", "walk":
 			cmdPrefix = parts[0] + " "
 			prefix = parts[1]
+		case "load":
+			if completion, ok := q.tc.complete(parts[1], pathCandidates(parts[1])); ok {
+				q.input.SetValue(parts[0] + " " + completion)
+				q.input.CursorEnd()
+			}
+			return
 		}
 	}
 
@@ -166,6 +188,38 @@ func (q *queryBarModel) tabComplete() {
 	q.input.CursorEnd()
 }
 
+// pathCandidates lists the entries of the directory a partial path points
+// into, as paths with the same directory prefix. Directories end in a slash
+// so completion can continue into them.
+func pathCandidates(partial string) []string {
+	dir, _ := filepath.Split(partial)
+	entries, err := os.ReadDir(expandHome(cmp.Or(dir, ".")))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		name := dir + e.Name()
+		if e.IsDir() {
+			name += string(filepath.Separator)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return home + path[1:]
+}
+
 func (q *queryBarModel) view() string {
 	var b strings.Builder
 	b.WriteString(q.input.View())
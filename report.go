@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// reportLineKind selects how a report line is styled on screen.
+type reportLineKind int
+
+const (
+	reportPlain reportLineKind = iota
+	reportHeading
+	reportGood
+	reportWarn
+	reportBad
+	reportMuted
+)
+
+type reportLine struct {
+	kind reportLineKind
+	text string
+}
+
+// reportBuilder accumulates report lines.
+type reportBuilder struct {
+	lines []reportLine
+}
+
+func (b *reportBuilder) add(kind reportLineKind, format string, args ...any) {
+	b.lines = append(b.lines, reportLine{kind: kind, text: fmt.Sprintf(format, args...)})
+}
+
+func (b *reportBuilder) blank() {
+	b.lines = append(b.lines, reportLine{})
+}
+
+// reportModel shows a generated text report (compliance checks, generated
+// modules) in the top-right pane. Reports are plain text underneath so they
+// can be written to a file unchanged.
+type reportModel struct {
+	viewportPane
+	title    string
+	fileName string // default file name when saving
+	lines    []reportLine
+}
+
+func newReportModel() reportModel {
+	return reportModel{viewportPane: newViewportPane()}
+}
+
+// set replaces the report content and scrolls to the top.
+func (r *reportModel) set(title, fileName string, lines []reportLine) {
+	r.title = title
+	r.fileName = fileName
+	r.lines = lines
+	r.vp.SetContent(r.buildContent())
+	r.vp.GotoTop()
+}
+
+func (r *reportModel) hasContent() bool {
+	return r.title != ""
+}
+
+func (r *reportModel) setSize(width, height int) {
+	r.viewportPane.setSize(width, height, 0)
+	r.vp.SetContent(r.buildContent())
+}
+
+func (r *reportModel) buildContent() string {
+	if !r.hasContent() {
+		return ""
+	}
+	var b strings.Builder
+	writeHeader(&b, r.title)
+	for _, l := range r.lines {
+		b.WriteString(reportLineStyle(l.kind).Render(l.text))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func reportLineStyle(kind reportLineKind) lipgloss.Style {
+	switch kind {
+	case reportHeading:
+		return styles.Label
+	case reportGood:
+		return styles.Status.SuccessMsg
+	case reportWarn:
+		return styles.Status.WarnMsg
+	case reportBad:
+		return styles.Status.ErrorMsg
+	case reportMuted:
+		return styles.Status.InfoMsg
+	default:
+		return styles.Value
+	}
+}
+
+func (r *reportModel) view() string {
+	if !r.hasContent() {
+		return styles.Label.Render("(no report)")
+	}
+	vpH := max(r.height, 1)
+	return attachScrollbar(r.vp.View(), vpH, r.vp.TotalLineCount(), r.vp.VisibleLineCount(), r.vp.YOffset())
+}
+
+// plainText returns the report without styling.
+func (r *reportModel) plainText() string {
+	var b strings.Builder
+	for _, l := range r.lines {
+		b.WriteString(l.text)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// save writes the report to its file name in the working directory and
+// returns the path written.
+func (r *reportModel) save() (string, error) {
+	if err := os.WriteFile(r.fileName, []byte(r.plainText()), 0o644); err != nil {
+		return "", err
+	}
+	return r.fileName, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/google/cel-go/cel"
+	"github.com/gosnmp/gosnmp"
+)
+
+// resultCelVars are the result-specific variables added on top of
+// celNodeVars. name, oid, and type_name are redefined per result: the
+// instance name, the instance OID, and the PDU type label. The object's
+// MIB type name moves to syntax.
+var resultCelVars = []celNodeVar{
+	{"instance", cel.StringType},
+	{"value", cel.DynType},
+	{"raw", cel.StringType},
+	{"syntax", cel.StringType},
+	{"violation", cel.StringType},
+}
+
+// resultCelFilter holds the compiled CEL program for result filtering.
+type resultCelFilter struct {
+	env        *cel.Env
+	program    cel.Program // nil when no valid expression
+	expr       string      // current expression text
+	err        string      // compilation error, "" if ok
+	evalErr    string      // first runtime eval error, "" if ok
+	envErr     string      // CEL environment init error, "" if ok
+	activation map[string]any
+	support    *agentSupport // discovery results for "implemented", may be nil
+}
+
+func newResultCelFilter() *resultCelFilter {
+	env, err := cel.NewEnv(celNodeEnvOptions(resultCelVars...)...)
+	if err != nil {
+		return &resultCelFilter{envErr: fmt.Sprintf("cel env init: %v", err)}
+	}
+	return &resultCelFilter{env: env}
+}
+
+func (f *resultCelFilter) compile(expr string) {
+	f.expr = expr
+	f.program = nil
+	f.err = ""
+	f.evalErr = ""
+
+	if expr == "" {
+		return
+	}
+	if f.envErr != "" {
+		f.err = f.envErr
+		return
+	}
+	f.program, f.err = compileBoolProgram(f.env, expr)
+}
+
+func (f *resultCelFilter) buildActivation(res *snmp.Result, m *mib.Mib) map[string]any {
+	vars := f.activation
+	if vars == nil {
+		vars = make(map[string]any, len(celNodeVars)+len(resultCelVars))
+		f.activation = vars
+	}
+
+	var node *mib.Node
+	oid, err := mib.ParseOID(res.OID)
+	if err == nil && m != nil {
+		node = m.LongestPrefixByOID(oid)
+	}
+	setNodeVars(vars, node, f.support)
+
+	vars["syntax"] = vars["type_name"]
+	vars["name"] = res.Name
+	vars["oid"] = strings.TrimPrefix(res.OID, ".")
+	vars["type_name"] = res.TypeName
+	vars["instance"] = ""
+	if node != nil && err == nil && len(oid) > len(node.OID()) {
+		vars["instance"] = oid[len(node.OID()):].String()
+	}
+	vars["value"] = celResultValue(res)
+	vars["raw"] = snmp.RawValue(res.PDU)
+	vars["violation"] = res.Violation
+	return vars
+}
+
+// celResultValue returns the typed value of a result: int for integer
+// types (including enums and counters), double for opaque floats, and the
+// formatted string otherwise.
+func celResultValue(res *snmp.Result) any {
+	if n, ok := snmp.IntegerValue(res.PDU); ok {
+		return n
+	}
+	switch v := res.PDU.Value.(type) {
+	case float32:
+		if res.PDU.Type == gosnmp.OpaqueFloat {
+			return float64(v)
+		}
+	case float64:
+		if res.PDU.Type == gosnmp.OpaqueDouble {
+			return v
+		}
+	}
+	return res.Value
+}
+
+func (f *resultCelFilter) eval(res *snmp.Result, m *mib.Mib) bool {
+	if f.program == nil {
+		return true
+	}
+	out, _, err := f.program.Eval(f.buildActivation(res, m))
+	if err != nil {
+		if f.evalErr == "" {
+			f.evalErr = err.Error()
+		}
+		return false
+	}
+	result, ok := out.Value().(bool)
+	return ok && result
+}
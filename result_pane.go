@@ -43,18 +43,34 @@ type resultModel struct {
 
 	// Result filtering (activated by "/" while results are focused)
 	filterInput textinput.Model
-	filterQuery string // current filter substring (lowercased)
-	filterIdx   []int  // indices into results that match the filter (flat mode)
+	filterQuery string           // current filter substring (lowercased) or CEL expression
+	filterIdx   []int            // indices into results that match the filter (flat mode)
+	filterCEL   bool             // CEL expression mode instead of substring match
+	celFilter   *resultCelFilter // compiled expression for CEL mode
 }
 
 func newResultModel() resultModel {
+	ti := newStyledInput(resultFilterPrompt, 256)
+	ti.Placeholder = "ctrl+e: CEL mode"
+	s := ti.Styles()
+	s.Focused.Placeholder = styles.Label
+	s.Blurred.Placeholder = styles.Label
+	ti.SetStyles(s)
+
 	return resultModel{
 		flatLV:      NewListView[struct{}](2),
 		treeLV:      NewListView[resultTreeRow](2),
-		filterInput: newStyledInput("/ results: ", 128),
+		filterInput: ti,
+		celFilter:   newResultCelFilter(),
 	}
 }
 
+// Result filter prompts for substring and CEL modes.
+const (
+	resultFilterPrompt    = "/ results: "
+	resultCelFilterPrompt = "/ results (cel): "
+)
+
 func (r *resultModel) setSize(width, height int) {
 	r.width = width
 	r.height = height
@@ -88,6 +104,15 @@ func (r *resultModel) appendResults(results []snmp.Result) {
 	g.Results = append(g.Results, results...)
 	r.invalidateNameW()
 
+	// Keep an active filter current as walk results stream in
+	if r.isFiltering() {
+		for i := len(g.Results) - len(results); i < len(g.Results); i++ {
+			if r.matchResult(&g.Results[i]) {
+				r.filterIdx = append(r.filterIdx, i)
+			}
+		}
+	}
+
 	// Update tree if in tree mode
 	if r.treeMode && r.resultTree != nil && r.mib != nil {
 		for i := range results {
@@ -334,8 +359,8 @@ func (r *resultModel) viewFlat(focused bool) string {
 	b.WriteString(styles.Header.Info.Render(header))
 	b.WriteByte('\n')
 
-	if g.Err != nil {
-		b.WriteString(styles.Status.ErrorMsg.Render("Error: " + g.Err.Error()))
+	if g.Err != nil && (!g.Partial || len(g.Results) == 0) {
+		b.WriteString(renderSNMPError(g.Err, r.mib))
 		return b.String()
 	}
 
@@ -394,6 +419,7 @@ func (r *resultModel) viewFlat(focused bool) string {
 		// Truncate value to fit
 		maxVal := contentW - nameW - typeColumnPad - valueEqSepWidth
 		valStr = truncate(valStr, maxVal)
+		flag := violationFlag(res.Violation, maxVal-lipgloss.Width(valStr))
 
 		var line string
 		if i == cursor && r.focused {
@@ -403,12 +429,13 @@ func (r *resultModel) viewFlat(focused bool) string {
 			tl := styles.Label.Background(selBg).Render(fmt.Sprintf("%-*s", typeColumnWidth, res.TypeName))
 			nm := styles.Value.Background(selBg).Render(padded)
 			eq := bg.Foreground(styles.Value.GetForeground()).Render(" = " + valStr)
-			line = renderSelectedLine(tl+sp+nm+eq, contentW, true)
+			fl := styles.Status.WarnMsg.Background(selBg).Render(flag)
+			line = renderSelectedLine(tl+sp+nm+eq+fl, contentW, true)
 		} else if i == cursor {
-			content := typLabel + " " + styles.Value.Render(padded) + " = " + valStr
+			content := typLabel + " " + styles.Value.Render(padded) + " = " + valStr + styles.Status.WarnMsg.Render(flag)
 			line = renderSelectedLine(content, contentW, false)
 		} else {
-			line = "  " + typLabel + " " + styles.Value.Render(padded) + " = " + valStr
+			line = "  " + typLabel + " " + styles.Value.Render(padded) + " = " + valStr + styles.Status.WarnMsg.Render(flag)
 		}
 
 		b.WriteString(line)
@@ -433,8 +460,8 @@ func (r *resultModel) viewTree(focused bool) string {
 	b.WriteString(styles.Header.Info.Render(header))
 	b.WriteByte('\n')
 
-	if g.Err != nil {
-		b.WriteString(styles.Status.ErrorMsg.Render("Error: " + g.Err.Error()))
+	if g.Err != nil && (!g.Partial || len(g.Results) == 0) {
+		b.WriteString(renderSNMPError(g.Err, r.mib))
 		return b.String()
 	}
 
@@ -461,6 +488,7 @@ type treeLeafParts struct {
 	name     string // node name (without OID suffix)
 	oid      string // raw OID string, empty if showRawOID is off
 	value    string // truncated display value
+	flag     string // out-of-spec marker, "" if the value conforms
 }
 
 // treeLeaf computes the content parts for a leaf (result-bearing) tree row.
@@ -482,6 +510,7 @@ func (r *resultModel) treeLeaf(node *resultTreeNode, depth, width int) treeLeafP
 
 	maxVal := width - nameW - typeColumnPad - valueEqSepWidth - depth*treeIndentWidth - treeLeafGutter
 	p.value = truncate(p.value, maxVal)
+	p.flag = violationFlag(node.result.Violation, maxVal-lipgloss.Width(p.value))
 	return p
 }
 
@@ -532,7 +561,8 @@ func (r *resultModel) renderTreeLeaf(row resultTreeRow, indent, icon string, wid
 		name += " " + styles.Subtle.Render("("+p.oid+")")
 	}
 	typLabel := styles.Label.Render(p.typeName)
-	return indent + icon + typLabel + " " + styles.Value.Render(name) + " = " + p.value
+	return indent + icon + typLabel + " " + styles.Value.Render(name) + " = " + p.value +
+		styles.Status.WarnMsg.Render(p.flag)
 }
 
 // renderTreeBranch builds a branch (non-leaf) tree row without selection background.
@@ -562,7 +592,8 @@ func (r *resultModel) renderFocusedTreeRow(row resultTreeRow, indent, icon strin
 		typLabel := styles.Label.Background(selBg).Render(p.typeName)
 		nameStr := styles.Value.Background(selBg).Render(name)
 		eqStr := bg.Foreground(styles.Value.GetForeground()).Render(" = " + p.value)
-		content = bg.Render(indent+icon) + typLabel + sp + nameStr + eqStr
+		flagStr := styles.Status.WarnMsg.Background(selBg).Render(p.flag)
+		content = bg.Render(indent+icon) + typLabel + sp + nameStr + eqStr + flagStr
 	} else {
 		bp := treeBranch(row.node)
 		var kindDot string
@@ -585,6 +616,12 @@ func (r *resultModel) headerLine(g *snmp.ResultGroup) string {
 	}
 	if r.walkStatus != "" {
 		header += "  " + IconLoading + " " + r.walkStatus
+	} else if g.Partial {
+		note := "partial: cancelled"
+		if g.Err != nil {
+			note = "partial: " + snmp.ErrorText(g.Err, r.mib)
+		}
+		header += "  " + styles.Status.WarnMsg.Render(note+" (s r resumes)")
 	}
 	if r.history.Len() > 1 {
 		header += fmt.Sprintf("  [%d/%d]", r.history.Index1(), r.history.Len())
@@ -608,11 +645,13 @@ func (r *resultModel) headerLine(g *snmp.ResultGroup) string {
 	return header
 }
 
-// activateFilter starts the result filter input.
+// activateFilter starts the result filter input. The substring/CEL mode
+// carries over from the previous filter.
 func (r *resultModel) activateFilter() {
 	r.filterInput.SetValue("")
 	r.filterQuery = ""
 	r.filterIdx = nil
+	r.celFilter.compile("")
 	r.filterInput.Focus()
 }
 
@@ -633,14 +672,38 @@ func (r *resultModel) isFiltering() bool {
 	return r.filterQuery != ""
 }
 
+// toggleFilterMode switches between substring and CEL filtering and
+// re-applies the current input in the new mode.
+func (r *resultModel) toggleFilterMode() {
+	r.filterCEL = !r.filterCEL
+	if r.filterCEL {
+		r.filterInput.Prompt = resultCelFilterPrompt
+		r.filterInput.Placeholder = "is_counter && value > 1000000  [ctrl+e: substring]"
+	} else {
+		r.filterInput.Prompt = resultFilterPrompt
+		r.filterInput.Placeholder = "ctrl+e: CEL mode"
+	}
+	r.applyFilter()
+}
+
 // applyFilter recomputes the filtered index set from the current filter query.
 func (r *resultModel) applyFilter() {
-	query := strings.ToLower(r.filterInput.Value())
+	var query string
+	if r.filterCEL {
+		expr := strings.TrimSpace(r.filterInput.Value())
+		r.celFilter.compile(expr)
+		if r.celFilter.program != nil {
+			query = expr
+		}
+	} else {
+		query = strings.ToLower(r.filterInput.Value())
+	}
 	r.filterQuery = query
 	r.invalidateNameW()
 
 	if query == "" {
 		r.filterIdx = nil
+		r.syncFlatRows()
 		return
 	}
 
@@ -651,11 +714,8 @@ func (r *resultModel) applyFilter() {
 	}
 
 	r.filterIdx = r.filterIdx[:0:0]
-	for i, res := range g.Results {
-		if strings.Contains(strings.ToLower(res.Name), query) ||
-			strings.Contains(res.OID, query) ||
-			strings.Contains(strings.ToLower(res.Value), query) ||
-			strings.Contains(strings.ToLower(res.TypeName), query) {
+	for i := range g.Results {
+		if r.matchResult(&g.Results[i]) {
 			r.filterIdx = append(r.filterIdx, i)
 		}
 	}
@@ -664,11 +724,29 @@ func (r *resultModel) applyFilter() {
 	r.syncFlatRows()
 }
 
+// matchResult reports whether a result passes the active filter.
+func (r *resultModel) matchResult(res *snmp.Result) bool {
+	if r.filterCEL {
+		return r.celFilter.eval(res, r.mib)
+	}
+	query := r.filterQuery
+	return strings.Contains(strings.ToLower(res.Name), query) ||
+		strings.Contains(res.OID, query) ||
+		strings.Contains(strings.ToLower(res.Value), query) ||
+		strings.Contains(strings.ToLower(res.TypeName), query)
+}
+
 // filterView renders the result filter input line.
 func (r *resultModel) filterView() string {
 	line := r.filterInput.View()
+	if r.filterCEL && r.celFilter.err != "" {
+		return line + styles.Status.ErrorMsg.Render("  "+r.celFilter.err)
+	}
 	if r.filterQuery != "" {
 		line += "  " + styles.Status.SuccessMsg.Render(matchBadge(len(r.filterIdx)))
+		if r.filterCEL && r.celFilter.evalErr != "" {
+			line += styles.Status.WarnMsg.Render("  eval: " + r.celFilter.evalErr)
+		}
 	}
 	return line
 }
@@ -0,0 +1,405 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+type rowEditMode int
+
+const (
+	rowEditCreate  rowEditMode = iota // new row via RowStatus createAndGo/createAndWait
+	rowEditModify                     // SET changed writable columns of an existing row
+	rowEditDestroy                    // RowStatus destroy confirmation
+)
+
+// rowEditUnset is the select option meaning "do not send this column".
+const rowEditUnset = "(unset)"
+
+// rowEditorSubmitMsg carries the varbinds built by the row editor.
+type rowEditorSubmitMsg struct {
+	label string
+	pdus  []gosnmp.SnmpPDU
+}
+
+// rowEditorField is a single form field bound to an index component or column.
+type rowEditorField struct {
+	obj      *mib.Object
+	isIndex  bool
+	isSelect bool
+	text     textinput.Model
+	sel      selectModel
+	initial  string // text value at open time (modify mode), used to detect changes
+	initSel  int    // select option at open time (modify mode), used to detect changes
+}
+
+func (f *rowEditorField) input() dialogInput {
+	if f.isSelect {
+		return dialogInput{sel: &f.sel}
+	}
+	return dialogInput{text: &f.text}
+}
+
+// rowEditorModel is a modal form for creating, editing, or destroying a
+// conceptual row. Fields are typed from each column's MIB definition.
+type rowEditorModel struct {
+	mode      rowEditMode
+	mib       *mib.Mib
+	table     *mib.Object
+	rowStatus *mib.Object // RowStatus column, nil if the table has none
+	indexes   []mib.IndexEntry
+	suffix    string // instance suffix of the edited row (modify/destroy)
+
+	fields  []rowEditorField
+	focused int
+	err     string
+}
+
+// newRowEditor builds a row editor for the given table. row is the selected
+// row for modify/destroy and may be nil for create.
+func newRowEditor(mode rowEditMode, tbl *mib.Object, row *snmp.TableRow, m *mib.Mib) (*rowEditorModel, error) {
+	entry := tbl.Entry()
+	if entry == nil {
+		return nil, errors.New("table has no row definition")
+	}
+	d := &rowEditorModel{
+		mode:      mode,
+		mib:       m,
+		table:     tbl,
+		rowStatus: snmp.RowStatusColumn(tbl),
+		indexes:   entry.EffectiveIndexes(),
+	}
+	if row != nil {
+		d.suffix = row.Suffix
+	}
+
+	switch mode {
+	case rowEditCreate:
+		if d.rowStatus == nil {
+			return nil, errors.New("table has no RowStatus column")
+		}
+		for _, idx := range d.indexes {
+			if idx.Object == nil {
+				return nil, fmt.Errorf("bare type index %s not supported", idx.TypeName)
+			}
+			f := newRowEditorField(idx.Object, "", false)
+			f.isIndex = true
+			d.fields = append(d.fields, f)
+		}
+		status := newRowEditorField(d.rowStatus, "", false)
+		status.isSelect = true
+		status.sel = newSelect([]string{"createAndGo", "createAndWait"})
+		d.fields = append(d.fields, status)
+	case rowEditDestroy:
+		if d.rowStatus == nil {
+			return nil, errors.New("table has no RowStatus column")
+		}
+		return d, nil
+	}
+
+	iset := snmp.IndexNameSet(d.indexes)
+	for i, col := range tbl.Columns() {
+		if !snmp.IsWritable(col) || iset[col.Name()] {
+			continue
+		}
+		if mode == rowEditCreate && col == d.rowStatus {
+			continue
+		}
+		var initial string
+		if row != nil && i < len(row.PDUs) && row.PDUs[i].Name != "" {
+			initial = snmp.EditValue(row.PDUs[i], col, m)
+		}
+		d.fields = append(d.fields, newRowEditorField(col, initial, true))
+	}
+
+	if len(d.fields) == 0 {
+		return nil, errors.New("no writable columns")
+	}
+	return d, nil
+}
+
+// newRowEditorField creates a text or select field for an object. Enumerated
+// objects get a select; allowUnset adds an option for leaving them out, which
+// is also what is selected when initial names no label (an absent cell, or a
+// value outside the enumeration).
+func newRowEditorField(obj *mib.Object, initial string, allowUnset bool) rowEditorField {
+	f := rowEditorField{obj: obj, initial: initial}
+	if enums := obj.EffectiveEnums(); len(enums) > 0 {
+		var opts []string
+		if allowUnset {
+			opts = append(opts, rowEditUnset)
+		}
+		for _, nv := range enums {
+			opts = append(opts, nv.Label)
+		}
+		f.isSelect = true
+		f.sel = newSelect(opts)
+		f.sel.SetValue(initial)
+		f.initSel = f.sel.Selected()
+		return f
+	}
+
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = snmp.TypeHint(obj)
+	ti.CharLimit = 256
+	s := ti.Styles()
+	s.Cursor = textinput.CursorStyle{
+		Color: palette.Primary,
+		Shape: tea.CursorBar,
+		Blink: true,
+	}
+	ti.SetStyles(s)
+	if initial != "" {
+		ti.SetValue(initial)
+	}
+	f.text = ti
+	return f
+}
+
+func (d *rowEditorModel) focusCmd() tea.Cmd {
+	if len(d.fields) == 0 {
+		return nil
+	}
+	return d.fields[d.focused].input().Focus()
+}
+
+func (d *rowEditorModel) cycle(delta int) tea.Cmd {
+	if len(d.fields) == 0 {
+		return nil
+	}
+	d.fields[d.focused].input().Blur()
+	d.focused = (d.focused + delta + len(d.fields)) % len(d.fields)
+	return d.focusCmd()
+}
+
+// focusFieldAt focuses the field at the given index.
+func (d *rowEditorModel) focusFieldAt(i int) tea.Cmd {
+	if i < 0 || i >= len(d.fields) {
+		return nil
+	}
+	d.fields[d.focused].input().Blur()
+	d.focused = i
+	return d.focusCmd()
+}
+
+// instanceOID returns the full instance OID of a column for the given suffix.
+func instanceOID(col *mib.Object, suffix string) string {
+	return col.OID().String() + "." + suffix
+}
+
+// build assembles the SET varbinds and an operation label from the form.
+func (d *rowEditorModel) build() (string, []gosnmp.SnmpPDU, error) {
+	switch d.mode {
+	case rowEditDestroy:
+		label := "DESTROY " + d.table.Name() + "." + d.suffix
+		return label, []gosnmp.SnmpPDU{{
+			Name:  instanceOID(d.rowStatus, d.suffix),
+			Type:  gosnmp.Integer,
+			Value: snmp.RowStatusDestroy,
+		}}, nil
+
+	case rowEditCreate:
+		var idxValues []string
+		var status string
+		for i := range d.fields {
+			f := &d.fields[i]
+			if f.isIndex {
+				idxValues = append(idxValues, f.input().Value())
+			} else if f.obj == d.rowStatus {
+				status = f.input().Value()
+			}
+		}
+		suffixOID, err := snmp.EncodeIndex(d.indexes, idxValues, d.mib)
+		if err != nil {
+			return "", nil, err
+		}
+		suffix := suffixOID.String()
+
+		statusVal := snmp.RowStatusCreateAndGo
+		if status == "createAndWait" {
+			statusVal = snmp.RowStatusCreateAndWait
+		}
+		pdus := []gosnmp.SnmpPDU{{
+			Name:  instanceOID(d.rowStatus, suffix),
+			Type:  gosnmp.Integer,
+			Value: statusVal,
+		}}
+		colPDUs, err := d.columnPDUs(suffix, false)
+		if err != nil {
+			return "", nil, err
+		}
+		return "CREATE " + d.table.Name() + "." + suffix, append(pdus, colPDUs...), nil
+
+	default: // rowEditModify
+		pdus, err := d.columnPDUs(d.suffix, true)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(pdus) == 0 {
+			return "", nil, errors.New("no changes")
+		}
+		return "SET " + d.table.Name() + "." + d.suffix, pdus, nil
+	}
+}
+
+// columnPDUs encodes the non-index column fields. Empty and unset fields are
+// skipped; with changedOnly, fields still holding their initial value are too.
+func (d *rowEditorModel) columnPDUs(suffix string, changedOnly bool) ([]gosnmp.SnmpPDU, error) {
+	var pdus []gosnmp.SnmpPDU
+	for i := range d.fields {
+		f := &d.fields[i]
+		if f.isIndex || (d.mode == rowEditCreate && f.obj == d.rowStatus) {
+			continue
+		}
+		val := f.input().Value()
+		if val == "" || val == rowEditUnset {
+			continue
+		}
+		if changedOnly {
+			if f.isSelect && f.sel.Selected() == f.initSel {
+				continue
+			}
+			if !f.isSelect && val == f.initial {
+				continue
+			}
+		}
+		pdu, err := snmp.EncodeValue(f.obj, instanceOID(f.obj, suffix), val, d.mib)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.obj.Name(), err)
+		}
+		pdus = append(pdus, pdu)
+	}
+	return pdus, nil
+}
+
+func (d *rowEditorModel) update(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "esc":
+		return nil, true
+	case "tab", "down":
+		return d.cycle(1), false
+	case "shift+tab", "up":
+		return d.cycle(-1), false
+	case "enter":
+		label, pdus, err := d.build()
+		if err != nil {
+			d.err = err.Error()
+			return nil, false
+		}
+		return func() tea.Msg {
+			return rowEditorSubmitMsg{label: label, pdus: pdus}
+		}, true
+	}
+
+	if len(d.fields) == 0 {
+		return nil, false
+	}
+	cmd := d.fields[d.focused].input().Update(msg)
+	d.err = ""
+	return cmd, false
+}
+
+// fieldStartLine returns the content line number where fields begin
+// (title + subtitle + blank).
+func (d *rowEditorModel) fieldStartLine() int {
+	return 3
+}
+
+// lineToField maps a content Y offset to a field index, or -1.
+func (d *rowEditorModel) lineToField(line int) int {
+	idx := line - d.fieldStartLine()
+	if idx >= 0 && idx < len(d.fields) {
+		return idx
+	}
+	return -1
+}
+
+func (d *rowEditorModel) title() string {
+	switch d.mode {
+	case rowEditCreate:
+		return "Create Row"
+	case rowEditDestroy:
+		return "Destroy Row"
+	}
+	return "Edit Row"
+}
+
+func (d *rowEditorModel) view() string {
+	var b strings.Builder
+	bg := palette.BgLighter
+
+	b.WriteString(styles.Dialog.Title.Background(bg).Render(d.title()))
+	b.WriteByte('\n')
+	sub := d.table.Name()
+	if d.suffix != "" {
+		sub += "." + d.suffix
+	}
+	b.WriteString(styles.Label.Background(bg).Render(sub))
+	b.WriteString("\n\n")
+
+	if d.mode == rowEditDestroy {
+		b.WriteString(styles.Status.WarnMsg.Background(bg).Render(
+			"Set " + d.rowStatus.Name() + " to destroy(6)?"))
+		b.WriteByte('\n')
+	}
+
+	labelW := 12
+	for _, f := range d.fields {
+		labelW = max(labelW, lipgloss.Width(f.obj.Name())+2)
+	}
+	labelW = min(labelW, 28)
+
+	for i := range d.fields {
+		f := &d.fields[i]
+		di := f.input()
+		name := truncate(f.obj.Name(), labelW-2) + ":"
+		lblStyle := styles.Label
+		if f.isIndex {
+			lblStyle = styles.Table.Index
+		}
+		b.WriteString(lblStyle.Background(bg).Render(fmt.Sprintf("%-*s", labelW, name)))
+		if i == d.focused {
+			b.WriteString(di.activeView())
+		} else {
+			val := di.Value()
+			if val == "" {
+				val = f.text.Placeholder
+				b.WriteString(styles.EmptyText.Background(bg).Render(val))
+			} else {
+				b.WriteString(styles.Value.Background(bg).Render(val))
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	if d.err != "" {
+		b.WriteByte('\n')
+		b.WriteString(styles.Status.ErrorMsg.Background(bg).Render(d.err))
+		b.WriteByte('\n')
+	}
+
+	b.WriteByte('\n')
+	const keyW = 7
+	keyStyle := styles.Label.Background(bg).Width(keyW)
+	valStyle := styles.Value.Background(bg)
+	if d.mode == rowEditDestroy {
+		b.WriteString(keyStyle.Render("enter") + valStyle.Render("destroy row") + "\n")
+	} else {
+		b.WriteString(keyStyle.Render("tab") + valStyle.Render("next field") + "\n")
+		b.WriteString(keyStyle.Render("\u2190/\u2192") + valStyle.Render("cycle options") + "\n")
+		b.WriteString(keyStyle.Render("enter") + valStyle.Render("send SET") + "\n")
+	}
+	b.WriteString(keyStyle.Render("esc") + valStyle.Render("cancel"))
+
+	content := padContentBg(b.String(), bg)
+	return lipgloss.NewStyle().Width(labelW + 36).Background(bg).Render(content)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+// testCtlRow returns a fetched testCtlTable row where only RowStatus is
+// present, the way an agent answers for a row it has just created.
+func testCtlRow(t *testing.T) (*rowEditorModel, *snmp.TableRow) {
+	t.Helper()
+	m := loadTestMib(t)
+	tbl := m.Object("testCtlTable")
+	if tbl == nil {
+		t.Fatal("testCtlTable not loaded")
+	}
+	cols := tbl.Columns()
+	row := &snmp.TableRow{Suffix: "1", PDUs: make([]gosnmp.SnmpPDU, len(cols))}
+	for i, col := range cols {
+		if col.Name() == "testCtlRowStatus" {
+			row.PDUs[i] = gosnmp.SnmpPDU{
+				Name:  instanceOID(col, "1"),
+				Type:  gosnmp.Integer,
+				Value: snmp.RowStatusActive,
+			}
+		}
+	}
+	d, err := newRowEditor(rowEditModify, tbl, row, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, row
+}
+
+func TestRowEditorModifyNoEdits(t *testing.T) {
+	d, _ := testCtlRow(t)
+	_, pdus, err := d.build()
+	if len(pdus) != 0 {
+		t.Fatalf("modify with no edits sent %d SETs: %v", len(pdus), pdus)
+	}
+	if err == nil || err.Error() != "no changes" {
+		t.Fatalf("err = %v, want no changes", err)
+	}
+}
+
+func TestRowEditorModifyOneEdit(t *testing.T) {
+	d, _ := testCtlRow(t)
+	for i := range d.fields {
+		if f := &d.fields[i]; f.obj.Name() == "testCtlAdminStatus" {
+			if got := f.sel.Value(); got != rowEditUnset {
+				t.Fatalf("absent enum opened as %q, want %q", got, rowEditUnset)
+			}
+			f.sel.SetValue("disabled")
+		}
+	}
+	_, pdus, err := d.build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pdus) != 1 || pdus[0].Value != 2 {
+		t.Fatalf("pdus = %v, want one SET of testCtlAdminStatus to 2", pdus)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/mibsh/internal/snmp"
+)
+
+const scanHeaderLines = 3 // header + column headers + separator
+
+// scanModel lists the agents found by a subnet sweep, in address order.
+type scanModel struct {
+	prefix     string
+	profile    snmp.Profile // credentials the sweep used, for connecting and saving
+	hits       []snmp.ScanHit
+	probed     int
+	total      int
+	running    bool
+	cancelling bool
+
+	lv    ListView[snmp.ScanHit]
+	width int
+}
+
+func newScanModel() scanModel {
+	return scanModel{lv: NewListView[snmp.ScanHit](scanHeaderLines)}
+}
+
+// start clears the list for a new sweep.
+func (s *scanModel) start(prefix string, p snmp.Profile, total int) {
+	s.prefix = prefix
+	s.profile = p
+	s.hits = nil
+	s.probed = 0
+	s.total = total
+	s.running = true
+	s.cancelling = false
+	s.lv.SetRows(nil)
+}
+
+// add records sweep progress, keeping the cursor on the selected agent.
+func (s *scanModel) add(hits []snmp.ScanHit, probed int) {
+	s.probed += probed
+	if len(hits) == 0 {
+		return
+	}
+	var sel netip.Addr
+	if h := s.lv.Selected(); h != nil {
+		sel = h.Addr
+	}
+	for _, h := range hits {
+		i, _ := slices.BinarySearchFunc(s.hits, h, func(a, b snmp.ScanHit) int {
+			return a.Addr.Compare(b.Addr)
+		})
+		s.hits = slices.Insert(s.hits, i, h)
+	}
+	s.lv.SetRows(s.hits)
+	if sel.IsValid() {
+		if i := slices.IndexFunc(s.hits, func(h snmp.ScanHit) bool { return h.Addr == sel }); i >= 0 {
+			s.lv.SetCursor(i)
+		}
+	}
+}
+
+func (s *scanModel) finish() {
+	s.running = false
+	s.cancelling = false
+}
+
+// selected returns the agent under the cursor, or nil.
+func (s *scanModel) selected() *snmp.ScanHit {
+	return s.lv.Selected()
+}
+
+func (s *scanModel) setSize(width, height int) {
+	s.width = width
+	s.lv.SetSize(width, height)
+}
+
+func (s *scanModel) clickRow(row int) {
+	if row >= 0 && row < s.lv.Len() {
+		s.lv.SetCursor(row)
+	}
+}
+
+// view renders the scan pane content.
+func (s *scanModel) view() string {
+	if s.prefix == "" {
+		return styles.EmptyText.Render("(no scan run)")
+	}
+
+	var b strings.Builder
+
+	header := fmt.Sprintf("SCAN %s | %d agents | %d/%d probed", s.prefix, len(s.hits), s.probed, s.total)
+	switch {
+	case s.cancelling:
+		header += " | cancelling..."
+	case s.running:
+		header += " " + IconLoading
+	}
+	b.WriteString(styles.Header.Info.Render(header))
+	b.WriteByte('\n')
+
+	if len(s.hits) == 0 {
+		if s.running {
+			b.WriteString(styles.EmptyText.Render(IconLoading + " Waiting for agents to answer..."))
+		} else {
+			b.WriteString(styles.EmptyText.Render("(no agents answered)"))
+		}
+		return b.String()
+	}
+
+	addrW, nameW, objW, rttW := 15, 16, 20, 8
+	for _, h := range s.hits {
+		addrW = max(addrW, len(h.Target()))
+		nameW = max(nameW, len(h.Name))
+		objW = max(objW, len(h.ObjectName))
+	}
+	addrW = min(addrW, 39)
+	nameW = min(nameW, 32)
+	objW = min(objW, 40)
+	descrW := max(10, s.width-addrW-nameW-objW-rttW-10)
+
+	hdr := fmt.Sprintf("  %-*s  %-*s  %-*s  %*s  %s",
+		addrW, "ADDRESS", nameW, "NAME", objW, "OBJECT ID", rttW, "RTT", "DESCRIPTION")
+	b.WriteString(styles.Header.Info.Render(truncate(hdr, s.width)))
+	b.WriteByte('\n')
+
+	sepW := addrW + nameW + objW + rttW + descrW + 10
+	b.WriteString("  " + styles.Table.Sep.Render(strings.Repeat("─", max(0, min(sepW, s.width-2)))))
+	b.WriteByte('\n')
+
+	vis := s.lv.VisibleRows()
+	offset := s.lv.Offset()
+	cursor := s.lv.Cursor()
+	end := min(offset+vis, len(s.hits))
+
+	for i := offset; i < end; i++ {
+		h := s.hits[i]
+
+		var line strings.Builder
+		if i == cursor {
+			line.WriteString(selectedBorder() + " ")
+		} else {
+			line.WriteString("  ")
+		}
+
+		line.WriteString(styles.Value.Render(fmt.Sprintf("%-*s", addrW, truncate(h.Target(), addrW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Value.Render(fmt.Sprintf("%-*s", nameW, truncate(h.Name, nameW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Label.Render(fmt.Sprintf("%-*s", objW, truncate(h.ObjectName, objW))))
+		line.WriteString("  ")
+		line.WriteString(styles.Label.Render(fmt.Sprintf("%*s", rttW, formatRTT(h.RTT))))
+		line.WriteString("  ")
+		line.WriteString(styles.Value.Render(truncate(firstLine(h.Descr), descrW)))
+
+		b.WriteString(line.String())
+		if i < end-1 {
+			b.WriteByte('\n')
+		}
+	}
+
+	return attachScrollbar(b.String(), vis, len(s.hits), vis, offset)
+}
+
+// firstLine returns s up to its first line break. Many agents put the
+// firmware build on later lines of sysDescr.
+func firstLine(s string) string {
+	if i := strings.IndexAny(s, "\r\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
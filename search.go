@@ -42,7 +42,16 @@ type searchModel struct {
 func newSearchModel(m *mib.Mib) searchModel {
 	ti := newStyledInput("/ ", 128)
 
-	// Build search index
+	sm := searchModel{
+		input: ti,
+		index: buildSearchIndex(m),
+	}
+	sm.list.SetSize(0, maxSearchVisible)
+	return sm
+}
+
+// buildSearchIndex builds an index entry for every node in m.
+func buildSearchIndex(m *mib.Mib) []searchEntry {
 	var index []searchEntry
 	for node := range m.Nodes() {
 		name := node.Name()
@@ -70,13 +79,7 @@ func newSearchModel(m *mib.Mib) searchModel {
 			bitLow:  bitBuf.String(),
 		})
 	}
-
-	sm := searchModel{
-		input: ti,
-		index: index,
-	}
-	sm.list.SetSize(0, maxSearchVisible)
-	return sm
+	return index
 }
 
 func (s *searchModel) setSize(width int) {
@@ -39,6 +39,9 @@ func (m model) snapshot() (tea.Model, tea.Cmd) {
 	}
 	if m.snmp.IsConnected() {
 		fmt.Fprintf(&b, "Connected: %s (%s)\n", m.snmp.Target, m.snmp.Version)
+		if m.identity.objectID != "" {
+			fmt.Fprintf(&b, "Device: %s (sysObjectID %s)\n", m.identity.label(), m.identity.objectID)
+		}
 	} else {
 		b.WriteString("Connected: no\n")
 	}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+)
+
+// errorStatus formats an operation failure for the status bar: the plain
+// explanation plus the most likely cause, when the error is classified.
+func errorStatus(prefix string, err error, m *mib.Mib) string {
+	text := prefix + ": " + snmp.ErrorText(err, m)
+	var se *snmp.Error
+	if errors.As(err, &se) && len(se.Causes) > 0 {
+		text += " (likely " + se.Causes[0] + ")"
+	}
+	return text
+}
+
+// renderSNMPError renders an operation failure for a pane body: the plain
+// explanation, what the agent or network actually reported, and the
+// likely causes.
+func renderSNMPError(err error, m *mib.Mib) string {
+	var b strings.Builder
+	b.WriteString(styles.Status.ErrorMsg.Render("Error: " + snmp.ErrorText(err, m)))
+	var se *snmp.Error
+	if !errors.As(err, &se) {
+		return b.String()
+	}
+	if d := se.Detail(); d != "" && d != se.Summary {
+		b.WriteByte('\n')
+		b.WriteString(styles.Label.Render("  reported: ") + styles.Value.Render(d))
+	}
+	if len(se.Causes) > 0 {
+		b.WriteByte('\n')
+		b.WriteString(styles.Label.Render("  likely causes:"))
+		for _, c := range se.Causes {
+			b.WriteByte('\n')
+			b.WriteString(styles.Value.Render("    - " + c))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golangsnmp/gomib/mib"
+)
+
+// sourceModel shows a MIB file in the top-right pane, highlighted, with the
+// module's diagnostics written under the lines they point at.
+type sourceModel struct {
+	viewportPane
+	path   string // file shown, empty when nothing is open
+	module string
+	lines  []string
+	target int // 1-based line opened at, 0 for none
+	notes  map[int][]mib.Diagnostic
+	rows   []int // content row of each source line, for scrolling
+}
+
+func newSourceModel() sourceModel {
+	return sourceModel{viewportPane: newViewportPane()}
+}
+
+// open shows content from path with the cursor line at target, annotated
+// with the diagnostics reported against module.
+func (s *sourceModel) open(path, module string, content []byte, target int, diags []mib.Diagnostic) {
+	s.path = path
+	s.module = module
+	s.target = target
+	s.setText(content, diags)
+	s.scrollToTarget()
+}
+
+// refresh rereads the shown file after a reload, keeping the scroll
+// position.
+func (s *sourceModel) refresh(content []byte, diags []mib.Diagnostic) {
+	offset := s.vp.YOffset()
+	s.setText(content, diags)
+	s.vp.SetYOffset(offset)
+}
+
+func (s *sourceModel) setText(content []byte, diags []mib.Diagnostic) {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	s.lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	s.notes = make(map[int][]mib.Diagnostic)
+	for _, d := range diags {
+		if d.Module == s.module && d.Line > 0 {
+			s.notes[d.Line] = append(s.notes[d.Line], d)
+		}
+	}
+	s.vp.SetContent(s.buildContent())
+}
+
+func (s *sourceModel) hasContent() bool {
+	return s.path != ""
+}
+
+func (s *sourceModel) setSize(width, height int) {
+	s.viewportPane.setSize(width, height, 0)
+	if s.hasContent() {
+		s.vp.SetContent(s.buildContent())
+	}
+}
+
+// scrollToTarget puts the target line a third of the way down the pane.
+func (s *sourceModel) scrollToTarget() {
+	if s.target < 1 || s.target > len(s.rows) {
+		s.vp.GotoTop()
+		return
+	}
+	s.vp.SetYOffset(max(0, s.rows[s.target-1]-s.vp.Height()/3))
+}
+
+func (s *sourceModel) buildContent() string {
+	var b strings.Builder
+	writeHeader(&b, fmt.Sprintf("%s (%s)", s.module, filepath.Base(s.path)))
+
+	row := 2 // below the header
+	numW := len(fmt.Sprint(len(s.lines)))
+	s.rows = s.rows[:0]
+	inString := false
+	for i, line := range s.lines {
+		n := i + 1
+		s.rows = append(s.rows, row)
+		num := fmt.Sprintf("%*d ", numW, n)
+		if n == s.target {
+			b.WriteString(styles.Source.Target.Render(BorderThick + num))
+		} else {
+			b.WriteString(styles.Source.Gutter.Render(" " + num))
+		}
+		b.WriteString(highlightSMI(expandTabs(line), &inString))
+		b.WriteByte('\n')
+		row++
+
+		for _, d := range s.notes[n] {
+			pad := strings.Repeat(" ", numW+2)
+			if d.Column > 0 {
+				pad += strings.Repeat(" ", d.Column-1) + "^ "
+			}
+			note := fmt.Sprintf("[%s] %s", d.Severity, d.Message)
+			if d.Code != "" {
+				note += " (" + d.Code + ")"
+			}
+			b.WriteString(diagSeverityStyle(d.Severity).Render(pad + note))
+			b.WriteByte('\n')
+			row++
+		}
+	}
+	return b.String()
+}
+
+func (s *sourceModel) view() string {
+	if !s.hasContent() {
+		return styles.Label.Render("(no source)")
+	}
+	vpH := max(s.height, 1)
+	return attachScrollbar(s.vp.View(), vpH, s.vp.TotalLineCount(), s.vp.VisibleLineCount(), s.vp.YOffset())
+}
+
+// expandTabs replaces tabs with spaces to 8-column stops, so diagnostic
+// columns line up with what is shown.
+func expandTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range line {
+		if r == '\t' {
+			n := 8 - col%8
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
+	return b.String()
+}
+
+// smiKeywords are the SMI macro names and clause keywords highlighted as
+// keywords.
+var smiKeywords = map[string]bool{
+	"DEFINITIONS": true, "BEGIN": true, "END": true, "IMPORTS": true, "FROM": true,
+	"EXPORTS": true, "MACRO": true, "OBJECT": true, "IDENTIFIER": true,
+	"MODULE-IDENTITY": true, "OBJECT-IDENTITY": true, "OBJECT-TYPE": true,
+	"NOTIFICATION-TYPE": true, "TRAP-TYPE": true, "TEXTUAL-CONVENTION": true,
+	"OBJECT-GROUP": true, "NOTIFICATION-GROUP": true, "MODULE-COMPLIANCE": true,
+	"AGENT-CAPABILITIES": true, "LAST-UPDATED": true, "ORGANIZATION": true,
+	"CONTACT-INFO": true, "DESCRIPTION": true, "REVISION": true, "REFERENCE": true,
+	"SYNTAX": true, "MAX-ACCESS": true, "ACCESS": true, "MIN-ACCESS": true,
+	"STATUS": true, "INDEX": true, "AUGMENTS": true, "IMPLIED": true,
+	"DEFVAL": true, "UNITS": true, "DISPLAY-HINT": true, "OBJECTS": true,
+	"NOTIFICATIONS": true, "ENTERPRISE": true, "VARIABLES": true, "MODULE": true,
+	"MANDATORY-GROUPS": true, "GROUP": true, "WRITE-SYNTAX": true,
+	"PRODUCT-RELEASE": true, "SUPPORTS": true, "INCLUDES": true,
+	"VARIATION": true, "CREATION-REQUIRES": true, "SEQUENCE": true, "OF": true,
+	"SIZE": true, "CHOICE": true, "INTEGER": true, "OCTET": true, "STRING": true,
+	"BITS": true,
+}
+
+// highlightSMI styles one line of SMI source. inString carries an open
+// quoted string from one line to the next; comments end at the line.
+func highlightSMI(line string, inString *bool) string {
+	st := styles.Source
+	var b strings.Builder
+	i := 0
+	for i < len(line) {
+		rest := line[i:]
+		switch c := line[i]; {
+		case *inString || c == '"':
+			start := 0
+			if !*inString {
+				start = 1 // past the opening quote
+			}
+			end := strings.IndexByte(rest[start:], '"')
+			if end < 0 {
+				*inString = true
+				b.WriteString(st.String.Render(rest))
+				return b.String()
+			}
+			*inString = false
+			n := start + end + 1
+			b.WriteString(st.String.Render(rest[:n]))
+			i += n
+		case strings.HasPrefix(rest, "--"):
+			n := len(rest)
+			if end := strings.Index(rest[2:], "--"); end >= 0 {
+				n = end + 4
+			}
+			b.WriteString(st.Comment.Render(rest[:n]))
+			i += n
+		case strings.HasPrefix(rest, "::="):
+			b.WriteString(st.Keyword.Render("::="))
+			i += 3
+		case isASCIILetter(c):
+			n := 1
+			for n < len(rest) && (isASCIILetter(rest[n]) || isASCIIDigit(rest[n]) ||
+				rest[n] == '-' && !strings.HasPrefix(rest[n:], "--")) {
+				n++
+			}
+			word := rest[:n]
+			switch {
+			case smiKeywords[word]:
+				b.WriteString(st.Keyword.Render(word))
+			case c >= 'A' && c <= 'Z':
+				b.WriteString(st.Type.Render(word))
+			default:
+				b.WriteString(styles.Value.Render(word))
+			}
+			i += n
+		case isASCIIDigit(c):
+			n := 1
+			for n < len(rest) && isASCIIDigit(rest[n]) {
+				n++
+			}
+			b.WriteString(st.Number.Render(rest[:n]))
+			i += n
+		default:
+			_, n := utf8.DecodeRuneInString(rest)
+			b.WriteString(rest[:n])
+			i += n
+		}
+	}
+	return b.String()
+}
+
+func isASCIILetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isASCIIDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// definitionKeywords are what follows a name where it is defined, as
+// opposed to where it is imported or referenced.
+var definitionKeywords = []string{
+	"OBJECT-TYPE", "OBJECT-IDENTITY", "MODULE-IDENTITY", "NOTIFICATION-TYPE",
+	"TRAP-TYPE", "OBJECT-GROUP", "NOTIFICATION-GROUP", "MODULE-COMPLIANCE",
+	"AGENT-CAPABILITIES", "TEXTUAL-CONVENTION", "MACRO", `OBJECT\s+IDENTIFIER`, "DEFINITIONS",
+	"::=",
+}
+
+// definitionLine returns the 1-based line where name is defined in a MIB
+// source, or 0 if no definition is found.
+func definitionLine(content []byte, name string) int {
+	if name == "" {
+		return 0
+	}
+	re, err := regexp.Compile(`(?m)^[ \t]*` + regexp.QuoteMeta(name) + `\s+(?:` +
+		strings.Join(definitionKeywords, "|") + `)`)
+	if err != nil {
+		return 0
+	}
+	loc := re.FindIndex(content)
+	if loc == nil {
+		return 0
+	}
+	return 1 + bytes.Count(content[:loc[0]], []byte{'\n'})
+}
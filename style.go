@@ -157,6 +157,9 @@ type appStyles struct {
 	// Context menu
 	ContextMenu contextMenuStyles
 
+	// Source viewer
+	Source sourceStyles
+
 	// Scrollbar
 	ScrollThumb lipgloss.Style
 	ScrollTrack lipgloss.Style
@@ -178,6 +181,9 @@ type treeStyles struct {
 	SelectedBg    lipgloss.Style // background-only style for selected row highlight
 	Deprecated    lipgloss.Style
 	Obsolete      lipgloss.Style
+
+	Implemented       lipgloss.Style // marker on subtrees the agent answered for
+	ImplementedWithin lipgloss.Style // marker on nodes containing answered subtrees
 }
 
 type statusStyles struct {
@@ -220,6 +226,16 @@ type tableStyles struct {
 	CurrentCol lipgloss.Style
 }
 
+type sourceStyles struct {
+	Gutter  lipgloss.Style // line numbers
+	Target  lipgloss.Style // marker and number of the line opened at
+	Keyword lipgloss.Style // SMI macro and clause keywords
+	Type    lipgloss.Style // capitalized names: types, modules
+	String  lipgloss.Style
+	Comment lipgloss.Style
+	Number  lipgloss.Style
+}
+
 type contextMenuStyles struct {
 	Sel    lipgloss.Style // selected item text
 	SelKey lipgloss.Style // selected item key hint
@@ -284,6 +300,9 @@ func defaultStyles() appStyles {
 				Background(p.BgSubtle),
 			Deprecated: lipgloss.NewStyle().Foreground(p.Muted).Faint(true),
 			Obsolete:   lipgloss.NewStyle().Foreground(p.Muted).Strikethrough(true),
+
+			Implemented:       lipgloss.NewStyle().Foreground(p.Green),
+			ImplementedWithin: lipgloss.NewStyle().Foreground(p.Green).Faint(true),
 		},
 
 		Status: statusStyles{
@@ -371,6 +390,16 @@ func defaultStyles() appStyles {
 				Foreground(p.Muted),
 		},
 
+		Source: sourceStyles{
+			Gutter:  lipgloss.NewStyle().Foreground(p.Dim),
+			Target:  lipgloss.NewStyle().Foreground(p.Primary).Bold(true),
+			Keyword: lipgloss.NewStyle().Foreground(p.Purple),
+			Type:    lipgloss.NewStyle().Foreground(p.Teal),
+			String:  lipgloss.NewStyle().Foreground(p.Yellow),
+			Comment: lipgloss.NewStyle().Foreground(p.Muted).Italic(true),
+			Number:  lipgloss.NewStyle().Foreground(p.Blue),
+		},
+
 		ScrollThumb: lipgloss.NewStyle().
 			Foreground(p.Primary),
 		ScrollTrack: lipgloss.NewStyle().
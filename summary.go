@@ -0,0 +1,349 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+const summaryReportTitle = "Device summary"
+
+// summaryValueLimit caps the instances collected under each summary OID,
+// so a chassis with thousands of entities cannot stall the summary.
+const summaryValueLimit = 5000
+
+// summaryRequest is an in-flight device summary collection.
+type summaryRequest struct {
+	label string // CollectCmd label, matched against the response
+	focus bool   // asked for by the user: show and focus the report when done
+}
+
+// summaryChassisShown is the number of chassis listed in a summary.
+const summaryChassisShown = 8
+
+// Subtrees and columns a device summary walks, from the standard MIBs.
+// They are walked by OID so the summary works whether or not the modules
+// are loaded.
+const (
+	oidSystem = "1.3.6.1.2.1.1"
+
+	oidIfAdminStatus = "1.3.6.1.2.1.2.2.1.7"
+	oidIfOperStatus  = "1.3.6.1.2.1.2.2.1.8"
+
+	oidEntPhysicalDescr       = "1.3.6.1.2.1.47.1.1.1.1.2"
+	oidEntPhysicalClass       = "1.3.6.1.2.1.47.1.1.1.1.5"
+	oidEntPhysicalSoftwareRev = "1.3.6.1.2.1.47.1.1.1.1.10"
+	oidEntPhysicalSerialNum   = "1.3.6.1.2.1.47.1.1.1.1.11"
+	oidEntPhysicalModelName   = "1.3.6.1.2.1.47.1.1.1.1.13"
+
+	oidHrSystemUptime   = "1.3.6.1.2.1.25.1.1"
+	oidHrStorageType    = "1.3.6.1.2.1.25.2.3.1.2"
+	oidHrStorageDescr   = "1.3.6.1.2.1.25.2.3.1.3"
+	oidHrStorageUnits   = "1.3.6.1.2.1.25.2.3.1.4"
+	oidHrStorageSize    = "1.3.6.1.2.1.25.2.3.1.5"
+	oidHrStorageUsed    = "1.3.6.1.2.1.25.2.3.1.6"
+	oidHrProcessorLoad  = "1.3.6.1.2.1.25.3.3.1.2"
+	oidHrStorageRam     = "1.3.6.1.2.1.25.2.1.2"
+	oidHrStorageVirtual = "1.3.6.1.2.1.25.2.1.3"
+)
+
+// summaryOIDs returns the OIDs a device summary collects, in walk order.
+func summaryOIDs() []string {
+	return []string{
+		oidSystem,
+		oidIfAdminStatus, oidIfOperStatus,
+		oidEntPhysicalClass, oidEntPhysicalDescr, oidEntPhysicalModelName,
+		oidEntPhysicalSerialNum, oidEntPhysicalSoftwareRev,
+		oidHrSystemUptime, oidHrProcessorLoad,
+		oidHrStorageType, oidHrStorageDescr, oidHrStorageUnits, oidHrStorageSize, oidHrStorageUsed,
+	}
+}
+
+// ifOperStatusNames are the IF-MIB ifOperStatus enumeration labels.
+var ifOperStatusNames = map[int64]string{
+	1: "up", 2: "down", 3: "testing", 4: "unknown",
+	5: "dormant", 6: "notPresent", 7: "lowerLayerDown",
+}
+
+// entPhysicalClassNames are the ENTITY-MIB PhysicalClass labels.
+var entPhysicalClassNames = map[int64]string{
+	1: "other", 2: "unknown", 3: "chassis", 4: "backplane", 5: "container",
+	6: "powerSupply", 7: "fan", 8: "sensor", 9: "module", 10: "port",
+	11: "stack", 12: "cpu", 13: "energyObject", 14: "battery", 15: "storageDrive",
+}
+
+// sysServicesLayers names the sysServices bits, by OSI layer.
+var sysServicesLayers = []struct {
+	layer int
+	name  string
+}{
+	{1, "physical"}, {2, "datalink"}, {3, "internet"}, {4, "end-to-end"}, {7, "applications"},
+}
+
+// summaryColumn indexes a walked column by instance suffix.
+func summaryColumn(pdus map[string][]gosnmp.SnmpPDU, col string) map[string]gosnmp.SnmpPDU {
+	out := make(map[string]gosnmp.SnmpPDU, len(pdus[col]))
+	prefix := col + "."
+	for _, pdu := range pdus[col] {
+		name := strings.TrimPrefix(pdu.Name, ".")
+		if suffix, ok := strings.CutPrefix(name, prefix); ok {
+			out[suffix] = pdu
+		}
+	}
+	return out
+}
+
+// sortedSuffixes returns a column's instance suffixes in OID order.
+func sortedSuffixes(col map[string]gosnmp.SnmpPDU) []string {
+	keys := make([]string, 0, len(col))
+	for k := range col {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, snmp.CompareOIDStrings)
+	return keys
+}
+
+// buildSummaryReport lays out the system group, interface states, entity
+// inventory and host resources an agent returned. Sections the agent does
+// not implement say so rather than disappearing.
+func buildSummaryReport(target string, id deviceIdentity, pdus map[string][]gosnmp.SnmpPDU, err error, m *mib.Mib) []reportLine {
+	var b reportBuilder
+	b.add(reportPlain, "Agent   %s", target)
+	if err != nil {
+		b.add(reportBad, "Incomplete: %s", errorStatus("collection stopped", err, m))
+	}
+	b.blank()
+
+	summarySystem(&b, id, summaryColumn(pdus, oidSystem), m)
+	b.blank()
+	summaryInterfaces(&b, summaryColumn(pdus, oidIfAdminStatus), summaryColumn(pdus, oidIfOperStatus))
+	b.blank()
+	summaryInventory(&b, pdus, m)
+	b.blank()
+	summaryResources(&b, pdus, m)
+	return b.lines
+}
+
+func summarySystem(b *reportBuilder, id deviceIdentity, sys map[string]gosnmp.SnmpPDU, m *mib.Mib) {
+	b.add(reportHeading, "System")
+	if len(sys) == 0 {
+		b.add(reportMuted, "  The agent returned no system group.")
+		return
+	}
+	text := func(arc string) string {
+		pdu, ok := sys[arc+".0"]
+		if !ok || !snmp.HasValue(pdu) {
+			return ""
+		}
+		return snmp.FormatPDUToResult(pdu, m).Value
+	}
+
+	row := func(label, value string) {
+		if value != "" {
+			b.add(reportPlain, "  %-12s %s", label, value)
+		}
+	}
+	row("Name", text("5"))
+	descr := strings.Split(strings.ReplaceAll(text("1"), "\r", ""), "\n")
+	row("Description", descr[0])
+	for _, line := range descr[1:] {
+		if line = strings.TrimSpace(line); line != "" {
+			b.add(reportPlain, "  %-12s %s", "", line)
+		}
+	}
+	if id.objectID != "" {
+		row("Device", id.label()+" ("+id.objectID+")")
+	} else {
+		row("Object ID", text("2"))
+	}
+	row("Uptime", text("3"))
+	row("Contact", text("4"))
+	row("Location", text("6"))
+	if pdu, ok := sys["7.0"]; ok {
+		if v, ok := snmp.IntegerValue(pdu); ok {
+			var layers []string
+			for _, l := range sysServicesLayers {
+				if v&(1<<(l.layer-1)) != 0 {
+					layers = append(layers, l.name)
+				}
+			}
+			row("Services", fmt.Sprintf("%s (%d)", strings.Join(layers, ", "), v))
+		}
+	}
+}
+
+func summaryInterfaces(b *reportBuilder, admin, oper map[string]gosnmp.SnmpPDU) {
+	b.add(reportHeading, "Interfaces (IF-MIB)")
+	if len(oper) == 0 {
+		b.add(reportMuted, "  The agent returned no ifTable.")
+		return
+	}
+
+	counts := make(map[int64]int)
+	adminUpOperDown := 0
+	for suffix, pdu := range oper {
+		v, _ := snmp.IntegerValue(pdu)
+		counts[v]++
+		if a, ok := admin[suffix]; ok && v != 1 {
+			if av, _ := snmp.IntegerValue(a); av == 1 {
+				adminUpOperDown++
+			}
+		}
+	}
+
+	var parts []string
+	for _, v := range slices.Sorted(maps.Keys(counts)) {
+		name, ok := ifOperStatusNames[v]
+		if !ok {
+			name = fmt.Sprintf("status %d", v)
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", counts[v], name))
+	}
+	b.add(reportPlain, "  %d interfaces: %s", len(oper), strings.Join(parts, ", "))
+	if adminUpOperDown > 0 {
+		b.add(reportWarn, "  %d enabled but not up (ifAdminStatus up, ifOperStatus not up)", adminUpOperDown)
+	}
+}
+
+func summaryInventory(b *reportBuilder, pdus map[string][]gosnmp.SnmpPDU, m *mib.Mib) {
+	b.add(reportHeading, "Inventory (ENTITY-MIB)")
+	class := summaryColumn(pdus, oidEntPhysicalClass)
+	if len(class) == 0 {
+		b.add(reportMuted, "  The agent returned no entPhysicalTable.")
+		return
+	}
+	descr := summaryColumn(pdus, oidEntPhysicalDescr)
+	model := summaryColumn(pdus, oidEntPhysicalModelName)
+	serial := summaryColumn(pdus, oidEntPhysicalSerialNum)
+	software := summaryColumn(pdus, oidEntPhysicalSoftwareRev)
+	text := func(col map[string]gosnmp.SnmpPDU, suffix string) string {
+		pdu, ok := col[suffix]
+		if !ok || !snmp.HasValue(pdu) {
+			return ""
+		}
+		return strings.TrimSpace(snmp.FormatPDUToResult(pdu, m).Value)
+	}
+
+	counts := make(map[int64]int)
+	var chassis []string
+	for _, suffix := range sortedSuffixes(class) {
+		v, _ := snmp.IntegerValue(class[suffix])
+		counts[v]++
+		if v == 3 { // chassis
+			chassis = append(chassis, suffix)
+		}
+	}
+
+	for i, suffix := range chassis {
+		if i == summaryChassisShown {
+			b.add(reportMuted, "  ... %d more chassis", len(chassis)-i)
+			break
+		}
+		name := text(model, suffix)
+		if name == "" {
+			name = text(descr, suffix)
+		}
+		line := "  Chassis      " + name
+		if s := text(serial, suffix); s != "" {
+			line += "  serial " + s
+		}
+		if s := text(software, suffix); s != "" {
+			line += "  software " + s
+		}
+		b.add(reportPlain, "%s", line)
+	}
+
+	var parts []string
+	for v := int64(1); v <= 15; v++ {
+		if counts[v] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[v], entPhysicalClassNames[v]))
+		}
+	}
+	b.add(reportPlain, "  %-12s %d entities: %s", "Components", len(class), strings.Join(parts, ", "))
+}
+
+func summaryResources(b *reportBuilder, pdus map[string][]gosnmp.SnmpPDU, m *mib.Mib) {
+	b.add(reportHeading, "CPU and memory (HOST-RESOURCES-MIB)")
+	load := summaryColumn(pdus, oidHrProcessorLoad)
+	types := summaryColumn(pdus, oidHrStorageType)
+	uptime := summaryColumn(pdus, oidHrSystemUptime)
+	if len(load) == 0 && len(types) == 0 && len(uptime) == 0 {
+		b.add(reportMuted, "  The agent returned no host resources.")
+		return
+	}
+
+	if pdu, ok := uptime["0"]; ok && snmp.HasValue(pdu) {
+		b.add(reportPlain, "  %-12s %s", "Host uptime", snmp.FormatPDUToResult(pdu, m).Value)
+	}
+
+	if len(load) > 0 {
+		var sum, busiest int64
+		for _, pdu := range load {
+			v, _ := snmp.IntegerValue(pdu)
+			sum += v
+			busiest = max(busiest, v)
+		}
+		avg := sum / int64(len(load))
+		kind := reportPlain
+		if avg >= 80 {
+			kind = reportWarn
+		}
+		b.add(kind, "  %-12s %d processors, average load %d%%, busiest %d%%", "CPU", len(load), avg, busiest)
+	}
+
+	descr := summaryColumn(pdus, oidHrStorageDescr)
+	units := summaryColumn(pdus, oidHrStorageUnits)
+	size := summaryColumn(pdus, oidHrStorageSize)
+	used := summaryColumn(pdus, oidHrStorageUsed)
+	for _, suffix := range sortedSuffixes(types) {
+		label := ""
+		switch strings.TrimPrefix(snmp.RawValue(types[suffix]), ".") {
+		case oidHrStorageRam:
+			label = "Memory"
+		case oidHrStorageVirtual:
+			label = "Virtual"
+		default:
+			continue
+		}
+		u, _ := snmp.IntegerValue(units[suffix])
+		total, _ := snmp.IntegerValue(size[suffix])
+		inUse, _ := snmp.IntegerValue(used[suffix])
+		if total <= 0 {
+			continue
+		}
+		pct := inUse * 100 / total
+		kind := reportPlain
+		if pct >= 90 {
+			kind = reportWarn
+		}
+		name := ""
+		if d, ok := descr[suffix]; ok {
+			name = "  " + snmp.FormatPDUToResult(d, m).Value
+		}
+		b.add(kind, "  %-12s %s of %s used (%d%%)%s", label, formatBytes(inUse*u), formatBytes(total*u), pct, name)
+	}
+}
+
+// formatBytes renders a byte count with a binary unit.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// summaryReportFile is the default file name for a saved summary.
+func summaryReportFile(target string) string {
+	return fmt.Sprintf("summary-%s.txt", strings.ToLower(capabilityIdent(target)))
+}
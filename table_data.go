@@ -2,9 +2,15 @@ package main
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"charm.land/bubbles/v2/textinput"
 	"charm.land/lipgloss/v2"
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
 )
 
 // tableDataModel displays live SNMP table data in columnar format.
@@ -13,20 +19,53 @@ type tableDataModel struct {
 	columns      []string      // column header names
 	indexCols    int           // number of leading columns that are index columns
 	hScroll      int           // horizontal scroll offset (in columns)
+	colCursor    int           // selected column (in effective columns), the one "o" sorts by
 	tableColumns []columnEntry // column visibility/ordering from picker
 
-	lv ListView[[]string] // row data, cursor, offset, scrolling
-
-	width int // kept for column-width calculations in view()
-
-	loading bool   // fetch in progress
-	err     error  // fetch error
-	fetchOp string // "TABLE ifTable" label
+	allRows  []snmp.TableRow         // fetched rows in walk order
+	sortCol  int                     // source column to sort by, -1 for walk order
+	sortDesc bool                    // descending sort
+	lv       ListView[snmp.TableRow] // filtered and sorted rows, cursor, offset, scrolling
+
+	// Row filtering (activated by "/" while table data is focused)
+	filterInput textinput.Model
+	filter      *tableRowFilter
+	tc          tabCompleter
+
+	width int      // kept for column-width calculations in view()
+	mib   *mib.Mib // names the varbind an SNMP error blames
+
+	loading    bool   // fetch in progress, no rows yet
+	refreshing bool   // re-fetch in progress, current rows stay visible
+	err        error  // fetch error
+	fetchOp    string // "TABLE ifTable" label
+
+	// Streaming fetch state
+	pending    []snmp.TableRow // rows of a refresh, shown when it completes
+	rowIndex   map[string]int  // instance suffix -> position in the rows being filled
+	fetchDone  int             // columns fetched so far
+	fetchTotal int             // columns being fetched, 0 when idle
+	viewAt     time.Time       // when applyView last ran during the fetch
 }
 
+// tableViewInterval is how often new rows streaming into a filtered or
+// sorted table are worked into the view. Cells of rows already shown
+// update in place; finishFetch applies the view once more at the end.
+const tableViewInterval = 250 * time.Millisecond
+
 func newTableDataModel() tableDataModel {
+	ti := newStyledInput("/ rows: ", 256)
+	ti.Placeholder = `ifOperStatus == 2 && ifInErrors > 0`
+	s := ti.Styles()
+	s.Focused.Placeholder = styles.Label
+	s.Blurred.Placeholder = styles.Label
+	ti.SetStyles(s)
+
 	return tableDataModel{
-		lv: NewListView[[]string](tableDataHeaderLines),
+		sortCol:     -1,
+		lv:          NewListView[snmp.TableRow](tableDataHeaderLines),
+		filterInput: ti,
+		filter:      newTableRowFilter(nil, nil),
 	}
 }
 
@@ -35,20 +74,112 @@ func (t *tableDataModel) setSize(width, height int) {
 	t.lv.SetSize(width, height)
 }
 
-func (t *tableDataModel) setData(tableName string, columns []string, rows [][]string, indexCols int) {
-	t.tableName = tableName
-	t.columns = columns
-	t.indexCols = indexCols
-	t.hScroll = 0
-	t.loading = false
+// beginFetch prepares for rows streamed by a table fetch. A refresh of the
+// same table keeps the cursor, scroll, sort, and filter, and its rows stay
+// visible until the fetch completes; a new table resets them and fills in
+// as cells arrive.
+func (t *tableDataModel) beginFetch(tbl *mib.Object, f *snmp.TableFetch) {
+	refresh := t.refreshing && t.tableName == tbl.Name()
+	t.tableName = tbl.Name()
+	t.columns = f.Columns
+	t.indexCols = f.IndexCols
 	t.err = nil
-	t.lv.SetRows(rows)
-	t.lv.GoTop()
+	t.fetchDone, t.fetchTotal = 0, f.Fetching
+	t.rowIndex = make(map[string]int)
+	t.pending = nil
+	if !refresh {
+		t.refreshing = false
+		t.allRows = nil
+		t.hScroll = 0
+		t.colCursor = 0
+		t.sortCol = -1
+		t.sortDesc = false
+		t.filterInput.SetValue("")
+		t.filter = newTableRowFilter(tbl, f.Columns)
+		t.applyView()
+		t.lv.GoTop()
+	}
+}
+
+// addCells merges fetched cells into their rows. Rows are kept in arrival
+// order until finishFetch puts them in instance order.
+func (t *tableDataModel) addCells(cells []snmp.TableCell, columnsDone int) {
+	t.fetchDone += columnsDone
+	rows := &t.allRows
+	if t.refreshing {
+		rows = &t.pending
+	}
+	added := false
+	for _, c := range cells {
+		i, ok := t.rowIndex[c.Suffix]
+		if !ok {
+			added = true
+			i = len(*rows)
+			t.rowIndex[c.Suffix] = i
+			*rows = append(*rows, snmp.TableRow{
+				Suffix:     c.Suffix,
+				Cells:      make([]string, len(t.columns)),
+				PDUs:       make([]gosnmp.SnmpPDU, len(t.columns)),
+				Violations: make([]string, len(t.columns)),
+			})
+		}
+		row := &(*rows)[i]
+		row.Cells[c.Col] = c.Text
+		row.PDUs[c.Col] = c.PDU
+		row.Violations[c.Col] = c.Violation
+	}
+	if !t.refreshing && added && (t.loading || time.Since(t.viewAt) >= tableViewInterval) {
+		t.loading = false
+		t.applyView()
+		t.viewAt = time.Now()
+	}
+}
+
+// finishFetch ends a streaming fetch: rows are put in instance order and
+// the view is applied to all of them. A cancelled refresh keeps the rows
+// it would have replaced.
+func (t *tableDataModel) finishFetch(cancelled bool) {
+	refresh := t.refreshing
+	t.loading = false
+	t.refreshing = false
+	t.fetchTotal = 0
+	t.rowIndex = nil
+	if refresh {
+		if cancelled {
+			t.pending = nil
+			return
+		}
+		t.allRows, t.pending = t.pending, nil
+	}
+	slices.SortStableFunc(t.allRows, func(a, b snmp.TableRow) int {
+		return snmp.CompareOIDStrings(a.Suffix, b.Suffix)
+	})
+	t.applyView()
+}
+
+// fetching reports whether a table fetch is streaming into the pane.
+func (t *tableDataModel) fetching() bool {
+	return t.fetchTotal > 0
+}
+
+// fetchColumns returns the names of the columns the picker leaves visible,
+// or nil when the picker has not been used, meaning all columns.
+func (t *tableDataModel) fetchColumns() []string {
+	if len(t.tableColumns) == 0 {
+		return nil
+	}
+	var names []string
+	for _, col := range t.effectiveColumns() {
+		names = append(names, col.name)
+	}
+	return names
 }
 
 func (t *tableDataModel) setError(err error) {
 	t.err = err
 	t.loading = false
+	t.refreshing = false
+	t.allRows = nil
 	t.lv.SetRows(nil)
 }
 
@@ -56,11 +187,176 @@ func (t *tableDataModel) setLoading(label string) {
 	t.loading = true
 	t.err = nil
 	t.fetchOp = label
+	t.allRows = nil
 	t.lv.SetRows(nil)
 	t.columns = nil
 	t.tableColumns = nil
 }
 
+// applyView rebuilds the visible rows from allRows, applying the row
+// filter and then the sort.
+func (t *tableDataModel) applyView() {
+	rows := t.allRows
+	if t.filter.program != nil {
+		t.filter.evalErr = ""
+		rows = make([]snmp.TableRow, 0, len(t.allRows))
+		for i := range t.allRows {
+			if t.filter.eval(&t.allRows[i]) {
+				rows = append(rows, t.allRows[i])
+			}
+		}
+	}
+	if t.sortCol >= 0 {
+		if t.filter.program == nil {
+			rows = slices.Clone(rows)
+		}
+		slices.SortStableFunc(rows, t.compareRows)
+	}
+	t.lv.SetRows(rows)
+}
+
+// compareRows orders two rows by the sort column. Index columns without a
+// fetched value compare by instance suffix. Missing values sort last in
+// either direction.
+func (t *tableDataModel) compareRows(a, b snmp.TableRow) int {
+	col := t.sortCol
+	var pa, pb gosnmp.SnmpPDU
+	if col < len(a.PDUs) {
+		pa = a.PDUs[col]
+	}
+	if col < len(b.PDUs) {
+		pb = b.PDUs[col]
+	}
+
+	hasA, hasB := snmp.HasValue(pa), snmp.HasValue(pb)
+	var c int
+	switch {
+	case hasA && hasB:
+		c = snmp.ComparePDU(pa, pb)
+	case col < t.indexCols:
+		c = snmp.CompareOIDStrings(a.Suffix, b.Suffix)
+	case hasA:
+		return -1
+	case hasB:
+		return 1
+	default:
+		return 0
+	}
+	if t.sortDesc {
+		return -c
+	}
+	return c
+}
+
+// cycleSort cycles the sort on the selected column: ascending,
+// descending, then back to walk order.
+func (t *tableDataModel) cycleSort() {
+	effCols := t.effectiveColumns()
+	if len(effCols) == 0 {
+		return
+	}
+	t.colCursor = min(t.colCursor, len(effCols)-1)
+	col := effCols[t.colCursor].srcIdx
+	switch {
+	case t.sortCol != col:
+		t.sortCol = col
+		t.sortDesc = false
+	case !t.sortDesc:
+		t.sortDesc = true
+	default:
+		t.sortCol = -1
+		t.sortDesc = false
+	}
+	t.applyView()
+}
+
+// sortLabel describes the current sort for status messages.
+func (t *tableDataModel) sortLabel() string {
+	if t.sortCol < 0 || t.sortCol >= len(t.columns) {
+		return "walk order"
+	}
+	dir := "ascending"
+	if t.sortDesc {
+		dir = "descending"
+	}
+	return t.columns[t.sortCol] + " " + dir
+}
+
+// activateFilter focuses the row filter input, keeping the current expression.
+func (t *tableDataModel) activateFilter() {
+	t.filterInput.SetWidth(max(20, t.width-4))
+	t.filterInput.Focus()
+}
+
+// deactivateFilter closes the row filter input.
+func (t *tableDataModel) deactivateFilter() {
+	t.filterInput.Blur()
+	t.tc.reset()
+}
+
+// clearFilter removes the active row filter.
+func (t *tableDataModel) clearFilter() {
+	t.filterInput.SetValue("")
+	t.filter.compile("")
+	t.applyView()
+}
+
+// isFiltering returns true if a row filter is active.
+func (t *tableDataModel) isFiltering() bool {
+	return t.filter.program != nil
+}
+
+// recompileFilter compiles the input expression and re-applies the view.
+func (t *tableDataModel) recompileFilter() {
+	if t.filterInput.Value() == t.filter.expr {
+		return
+	}
+	t.filter.compile(t.filterInput.Value())
+	t.applyView()
+}
+
+// tabComplete completes the column name before the cursor.
+func (t *tableDataModel) tabComplete() {
+	value := t.filterInput.Value()
+	cursor := min(t.filterInput.Position(), len(value))
+	start := cursor
+	for start > 0 && isIdentByte(value[start-1]) {
+		start--
+	}
+	completion, ok := t.tc.complete(value[start:cursor], t.filter.idents)
+	if !ok {
+		return
+	}
+	t.filterInput.SetValue(value[:start] + completion + value[cursor:])
+	t.filterInput.SetCursor(start + len(completion))
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// filterView renders the row filter input line.
+func (t *tableDataModel) filterView() string {
+	var b strings.Builder
+	b.WriteString(t.filterInput.View())
+	if t.filter.err != "" {
+		b.WriteString(styles.Status.ErrorMsg.Render("  " + t.filter.err))
+	} else if t.filter.program != nil {
+		b.WriteString(styles.Status.SuccessMsg.Render("  " + matchBadge(t.lv.Len())))
+		if t.filter.evalErr != "" {
+			b.WriteString(styles.Status.WarnMsg.Render("  eval: " + t.filter.evalErr))
+		}
+	}
+	return b.String()
+}
+
+// setRefreshing marks a re-fetch of the current table. Unlike setLoading,
+// rows, cursor, and column picker state are kept until new data arrives.
+func (t *tableDataModel) setRefreshing() {
+	t.refreshing = true
+	t.err = nil
+}
+
 func (t *tableDataModel) scrollRight() {
 	effCount := len(t.effectiveColumns())
 	if effCount == 0 {
@@ -69,12 +365,51 @@ func (t *tableDataModel) scrollRight() {
 	if t.hScroll < effCount-1 {
 		t.hScroll++
 	}
+	t.colCursor = max(t.colCursor, t.hScroll)
 }
 
 func (t *tableDataModel) scrollLeft() {
 	if t.hScroll > 0 {
 		t.hScroll--
 	}
+	if effCols := t.effectiveColumns(); len(effCols) > 0 {
+		t.colCursor = min(t.colCursor, t.lastVisibleColumn(effCols))
+	}
+}
+
+// columnLeft and columnRight move the column selection, scrolling to keep
+// it in view.
+func (t *tableDataModel) columnLeft() {
+	if t.colCursor > 0 {
+		t.colCursor--
+	}
+	t.hScroll = min(t.hScroll, t.colCursor)
+}
+
+func (t *tableDataModel) columnRight() {
+	effCols := t.effectiveColumns()
+	if t.colCursor < len(effCols)-1 {
+		t.colCursor++
+	}
+	for t.hScroll < t.colCursor && t.lastVisibleColumn(effCols) < t.colCursor {
+		t.hScroll++
+	}
+}
+
+// lastVisibleColumn returns the last effective column that fits in the pane
+// when scrolled to hScroll.
+func (t *tableDataModel) lastVisibleColumn(effCols []tableDataCol) int {
+	widths := t.colWidths(effCols)
+	last := t.hScroll
+	usedW := 2 // left gutter (selection indicator)
+	for i := t.hScroll; i < len(widths); i++ {
+		usedW += widths[i] + 2 // 2 chars gap between columns
+		if usedW > t.width && i > t.hScroll {
+			break
+		}
+		last = i
+	}
+	return last
 }
 
 // clickRow sets the cursor to the given row index if in range.
@@ -85,12 +420,9 @@ func (t *tableDataModel) clickRow(row int) {
 	}
 }
 
-// selectedRow returns the row data at the cursor, or nil if out of range.
-func (t *tableDataModel) selectedRow() []string {
-	if sel := t.lv.Selected(); sel != nil {
-		return *sel
-	}
-	return nil
+// selectedRow returns the row at the cursor, or nil if out of range.
+func (t *tableDataModel) selectedRow() *snmp.TableRow {
+	return t.lv.Selected()
 }
 
 // tableDataCol maps a visible column to its source data index.
@@ -143,6 +475,18 @@ func (t *tableDataModel) effectiveColumns() []tableDataCol {
 	return cols
 }
 
+// headerName returns the column header text, with a sort direction marker
+// on the sorted column.
+func (t *tableDataModel) headerName(col tableDataCol) string {
+	if col.srcIdx != t.sortCol {
+		return col.name
+	}
+	if t.sortDesc {
+		return col.name + "\u25be"
+	}
+	return col.name + "\u25b4"
+}
+
 // colWidths computes the display width for each effective column, based on
 // header names and data content.
 func (t *tableDataModel) colWidths(effCols []tableDataCol) []int {
@@ -151,12 +495,12 @@ func (t *tableDataModel) colWidths(effCols []tableDataCol) []int {
 	}
 	widths := make([]int, len(effCols))
 	for i, col := range effCols {
-		widths[i] = lipgloss.Width(col.name)
+		widths[i] = lipgloss.Width(t.headerName(col))
 	}
 	for _, row := range t.lv.Rows() {
 		for i, col := range effCols {
-			if col.srcIdx < len(row) {
-				w := lipgloss.Width(row[col.srcIdx])
+			if col.srcIdx < len(row.Cells) {
+				w := lipgloss.Width(t.cellText(&row, col.srcIdx))
 				if w > widths[i] {
 					widths[i] = w
 				}
@@ -172,8 +516,12 @@ func (t *tableDataModel) colWidths(effCols []tableDataCol) []int {
 
 func (t *tableDataModel) view() string {
 	if t.loading {
+		text := IconLoading + " Fetching table data..."
+		if t.fetching() {
+			text += fmt.Sprintf(" (%d/%d columns)", t.fetchDone, t.fetchTotal)
+		}
 		return styles.Header.Info.Render(t.fetchOp) + "\n" +
-			styles.EmptyText.Render(IconLoading+" Fetching table data...")
+			styles.EmptyText.Render(text)
 	}
 
 	if t.err != nil {
@@ -182,7 +530,7 @@ func (t *tableDataModel) view() string {
 			header = "TABLE"
 		}
 		return styles.Header.Info.Render(header) + "\n" +
-			styles.Status.ErrorMsg.Render("Error: "+t.err.Error())
+			renderSNMPError(t.err, t.mib)
 	}
 
 	rows := t.lv.Rows()
@@ -191,8 +539,12 @@ func (t *tableDataModel) view() string {
 		if t.tableName != "" {
 			header = "TABLE " + t.tableName
 		}
+		empty := "(no data)"
+		if len(t.allRows) > 0 {
+			empty = fmt.Sprintf("(no rows match filter, %d total)", len(t.allRows))
+		}
 		return styles.Header.Info.Render(header) + "\n" +
-			styles.EmptyText.Render("(no data)")
+			styles.EmptyText.Render(empty)
 	}
 
 	effCols := t.effectiveColumns()
@@ -205,10 +557,29 @@ func (t *tableDataModel) view() string {
 
 	// Header line
 	header := fmt.Sprintf("TABLE %s (%d rows)", t.tableName, len(rows))
+	if len(rows) != len(t.allRows) {
+		header = fmt.Sprintf("TABLE %s (%d of %d rows)", t.tableName, len(rows), len(t.allRows))
+	}
 	if t.hScroll > 0 {
 		header += fmt.Sprintf("  [scroll: +%d cols]", t.hScroll)
 	}
+	if t.fetching() {
+		verb := "fetching"
+		if t.refreshing {
+			verb = "refreshing"
+		}
+		header += fmt.Sprintf("  %s %s %d/%d columns", IconLoading, verb, t.fetchDone, t.fetchTotal)
+	}
 	b.WriteString(styles.Header.Info.Render(header))
+	if sel := t.selectedRow(); sel != nil {
+		for i, v := range sel.Violations {
+			if v != "" && i < len(t.columns) {
+				b.WriteString(styles.Status.WarnMsg.Render(
+					violationFlag(t.columns[i]+": "+v, t.width-lipgloss.Width(header))))
+				break
+			}
+		}
+	}
 	b.WriteByte('\n')
 
 	allWidths := t.colWidths(effCols)
@@ -237,10 +608,13 @@ func (t *tableDataModel) view() string {
 	b.WriteString("  ") // gutter
 	for j, vc := range visCols {
 		col := effCols[vc.effIdx]
-		padded := fmt.Sprintf("%-*s", vc.width, truncate(col.name, vc.width))
-		if col.isIndex {
+		padded := fmt.Sprintf("%-*s", vc.width, truncate(t.headerName(col), vc.width))
+		switch {
+		case vc.effIdx == t.colCursor:
+			b.WriteString(styles.Table.CurrentCol.Render(padded))
+		case col.isIndex:
 			b.WriteString(styles.Table.Index.Render(padded))
-		} else {
+		default:
 			b.WriteString(styles.Header.Info.Render(padded))
 		}
 		if j < len(visCols)-1 {
@@ -269,7 +643,7 @@ func (t *tableDataModel) view() string {
 	}
 
 	for i := offset; i < end; i++ {
-		row := rows[i]
+		row := &rows[i]
 
 		var line strings.Builder
 		// Selection gutter
@@ -282,11 +656,13 @@ func (t *tableDataModel) view() string {
 		for j, vc := range visCols {
 			col := effCols[vc.effIdx]
 			cell := ""
-			if col.srcIdx < len(row) {
-				cell = row[col.srcIdx]
+			if col.srcIdx < len(row.Cells) {
+				cell = t.cellText(row, col.srcIdx)
 			}
 			padded := fmt.Sprintf("%-*s", vc.width, truncate(cell, vc.width))
-			if col.isIndex {
+			if cellViolation(row, col.srcIdx) != "" {
+				line.WriteString(styles.Status.WarnMsg.Render(padded))
+			} else if col.isIndex {
 				line.WriteString(styles.Table.Index.Render(padded))
 			} else {
 				line.WriteString(styles.Value.Render(padded))
@@ -304,3 +680,27 @@ func (t *tableDataModel) view() string {
 
 	return attachScrollbar(b.String(), vis, len(rows), vis, offset)
 }
+
+// cellText returns a cell's display text, prefixed with a warning marker
+// when the value is out of spec. A cell the agent did not return shows as
+// "-", unlike one it returned empty, once the fetch can no longer fill it.
+func (t *tableDataModel) cellText(row *snmp.TableRow, idx int) string {
+	if idx >= len(row.PDUs) || row.PDUs[idx].Name == "" {
+		if t.fetching() && !t.refreshing {
+			return ""
+		}
+		return "-"
+	}
+	if cellViolation(row, idx) != "" {
+		return IconWarn + " " + row.Cells[idx]
+	}
+	return row.Cells[idx]
+}
+
+// cellViolation returns the out-of-spec description for a cell, or "".
+func cellViolation(row *snmp.TableRow, idx int) string {
+	if idx < len(row.Violations) {
+		return row.Violations[idx]
+	}
+	return ""
+}
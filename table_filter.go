@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+)
+
+// celIdentRe matches names usable as CEL identifiers.
+var celIdentRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// tableFilterVar binds a table column or INDEX component to a CEL variable.
+type tableFilterVar struct {
+	ident   string
+	srcIdx  int  // index into row cells/PDUs, -1 for an INDEX object outside the table
+	index   int  // INDEX component position, -1 if not part of the index
+	integer bool // int variable from the PDU value; string from the cell otherwise
+}
+
+// tableRowFilter holds a CEL program evaluated against table data rows.
+// The environment is built per table so every column is a typed variable:
+// integer-valued columns (including enums and counters) are int, the rest
+// are the formatted cell text. Variables are nullable: a cell the agent did
+// not return is null, so it matches neither `== 0` nor `== ""`. INDEX
+// components come from the instance suffix when their column is
+// not-accessible or belongs to another table, and the raw suffix is
+// available as "index".
+type tableRowFilter struct {
+	env        *cel.Env
+	program    cel.Program // nil when no valid expression
+	expr       string      // current expression text
+	err        string      // compilation error, "" if ok
+	evalErr    string      // first runtime eval error, "" if ok
+	vars       []tableFilterVar
+	indexes    []mib.IndexEntry // INDEX components of the table's entry
+	idents     []string         // variable names, for tab completion
+	activation map[string]any   // reusable activation map for eval calls
+	absent     bool             // the last activation bound a null value
+}
+
+// newTableRowFilter builds a CEL environment for the table's columns.
+// names are the column headers, in the order of tbl.Columns().
+func newTableRowFilter(tbl *mib.Object, names []string) *tableRowFilter {
+	f := &tableRowFilter{}
+	opts := []cel.EnvOption{
+		cel.Variable("index", cel.StringType),
+		ext.Strings(),
+	}
+	f.idents = append(f.idents, "index")
+
+	var cols []*mib.Object
+	indexPos := make(map[*mib.Object]int)
+	if tbl != nil {
+		cols = tbl.Columns()
+		if entry := tbl.Entry(); entry != nil {
+			f.indexes = entry.EffectiveIndexes()
+			for i, idx := range f.indexes {
+				if idx.Object != nil {
+					indexPos[idx.Object] = i
+				}
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	addVar := func(name string, obj *mib.Object, srcIdx int) {
+		ident := strings.ReplaceAll(name, "-", "_")
+		if !celIdentRe.MatchString(ident) || ident == "index" || seen[ident] {
+			return
+		}
+		seen[ident] = true
+		v := tableFilterVar{ident: ident, srcIdx: srcIdx, index: -1}
+		if obj != nil {
+			if i, ok := indexPos[obj]; ok {
+				v.index = i
+			}
+			if obj.Type() != nil {
+				v.integer = isNumericBase(obj.Type().EffectiveBase())
+			}
+		}
+		typ := cel.StringType
+		if v.integer {
+			typ = cel.IntType
+		}
+		opts = append(opts, cel.Variable(ident, cel.NullableType(typ)))
+		f.vars = append(f.vars, v)
+		f.idents = append(f.idents, ident)
+	}
+	for i, name := range names {
+		var obj *mib.Object
+		if i < len(cols) {
+			obj = cols[i]
+		}
+		addVar(name, obj, i)
+	}
+	for _, idx := range f.indexes {
+		if idx.Object != nil && !slices.Contains(cols, idx.Object) {
+			addVar(idx.Object.Name(), idx.Object, -1)
+		}
+	}
+
+	env, err := cel.NewEnv(opts...)
+	if err != nil {
+		f.err = fmt.Sprintf("cel env init: %v", err)
+		return f
+	}
+	f.env = env
+	return f
+}
+
+func (f *tableRowFilter) compile(expr string) {
+	f.expr = expr
+	f.program = nil
+	f.err = ""
+	f.evalErr = ""
+
+	if expr == "" {
+		return
+	}
+	if f.env == nil {
+		f.err = "no table data"
+		return
+	}
+	f.program, f.err = compileBoolProgram(f.env, expr)
+}
+
+func (f *tableRowFilter) buildActivation(row *snmp.TableRow) map[string]any {
+	vars := f.activation
+	if vars == nil {
+		vars = make(map[string]any, len(f.vars)+1)
+		f.activation = vars
+	}
+	f.absent = false
+
+	vars["index"] = row.Suffix
+	var comps []mib.OID
+	if len(f.indexes) > 0 {
+		if suffix, err := mib.ParseOID(row.Suffix); err == nil {
+			comps, _ = snmp.DecodeIndex(f.indexes, suffix)
+		}
+	}
+	for _, v := range f.vars {
+		var val any
+		switch {
+		case v.srcIdx >= 0 && v.srcIdx < len(row.PDUs) && snmp.HasValue(row.PDUs[v.srcIdx]):
+			if !v.integer {
+				val = row.Cells[v.srcIdx]
+			} else if n, ok := snmp.IntegerValue(row.PDUs[v.srcIdx]); ok {
+				val = n
+			}
+		case v.index >= 0 && v.index < len(comps):
+			val = indexComponentValue(f.indexes[v.index], comps[v.index], v.integer)
+		}
+		if val == nil {
+			f.absent = true
+			val = types.NullValue
+		}
+		vars[v.ident] = val
+	}
+	return vars
+}
+
+// indexComponentValue converts the arcs of one INDEX component to the value
+// its variable holds: a number, a dotted address or OID, or the string.
+func indexComponentValue(idx mib.IndexEntry, arcs mib.OID, integer bool) any {
+	switch {
+	case integer:
+		if len(arcs) != 1 {
+			return nil
+		}
+		return int64(arcs[0])
+	case idx.Encoding == mib.IndexEncodingIpAddress:
+		return arcs.String()
+	case idx.Object.Type() != nil && idx.Object.Type().EffectiveBase() == mib.BaseObjectIdentifier:
+		return arcs.String()
+	}
+	b := make([]byte, len(arcs))
+	for i, a := range arcs {
+		if a > 255 {
+			return nil
+		}
+		b[i] = byte(a)
+	}
+	return string(b)
+}
+
+func (f *tableRowFilter) eval(row *snmp.TableRow) bool {
+	if f.program == nil {
+		return true
+	}
+	out, _, err := f.program.Eval(f.buildActivation(row))
+	if err != nil {
+		// Comparing a missing value fails; that row just does not match.
+		if f.evalErr == "" && !f.absent {
+			f.evalErr = err.Error()
+		}
+		return false
+	}
+	result, ok := out.Value().(bool)
+	return ok && result
+}
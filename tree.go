@@ -27,7 +27,8 @@ type treeModel struct {
 	filterMatch  map[string]bool // OID -> in filtered set (match or ancestor)
 	filterDirect map[string]bool // OID -> directly matches expression
 	filterActive bool
-	focused      bool // set during view() to control border style
+	support      *agentSupport // capability discovery results, shared with filters
+	focused      bool          // set during view() to control border style
 }
 
 func newTreeModel(root *mib.Node) treeModel {
@@ -58,6 +59,13 @@ func (t *treeModel) autoExpand() {
 	}
 }
 
+// setRoot replaces the tree after a MIB reload. Expansion is keyed by OID,
+// so it carries over to nodes that kept theirs.
+func (t *treeModel) setRoot(root *mib.Node) {
+	t.root = root
+	t.rebuild()
+}
+
 // rebuild flattens the tree according to current expanded state.
 func (t *treeModel) rebuild() {
 	if t.filterActive && t.filter != nil && t.filter.program != nil {
@@ -282,7 +290,7 @@ func (t *treeModel) renderRowFn(row treeRow, _ int, selected bool, width int) st
 		return t.renderSelectedRow(row, width)
 	}
 	line := t.renderRow(row)
-	return t.styleRow(row, line)
+	return t.styleRow(row, line) + t.support.treeMarker(row.node, lipgloss.NewStyle())
 }
 
 // renderSelectedRow renders the cursor row with thick left border, kind dot,
@@ -294,11 +302,13 @@ func (t *treeModel) renderSelectedRow(row treeRow, width int) string {
 
 	if !t.focused {
 		text := kindStyle(row.node.Kind()).Render(indent + icon + label + fmt.Sprintf("(%d)", row.node.Arc()))
+		text += t.support.treeMarker(row.node, lipgloss.NewStyle())
 		return renderSelectedLine(text, width, false)
 	}
 
 	bg := styles.Tree.SelectedBg
 	text := bg.Foreground(styles.Value.GetForeground()).Render(indent + icon + label + fmt.Sprintf("(%d)", row.node.Arc()))
+	text += t.support.treeMarker(row.node, bg)
 	return renderSelectedLine(text, width, true)
 }
 
@@ -86,6 +86,14 @@ func (tm *typeModel) toggleExpand() {
 	}
 }
 
+// selectedType returns the type under the cursor, or nil.
+func (tm *typeModel) selectedType() *mib.Type {
+	if i := tm.selectedItem(); i >= 0 && i < len(tm.filtered) {
+		return tm.filtered[i]
+	}
+	return nil
+}
+
 // rebuild regenerates the flattened view lines from the current filtered list.
 func (tm *typeModel) rebuild() {
 	tm.rebuildViewLines(len(tm.filtered),
@@ -116,7 +124,7 @@ func (tm *typeModel) view() string {
 	case tcFilterNonTC:
 		tcLabel = "non-TC"
 	}
-	status := fmt.Sprintf("types: %d/%d  [tab] %s  [enter] expand", len(tm.filtered), len(tm.all), tcLabel)
+	status := fmt.Sprintf("types: %d/%d  [tab] %s  [enter] expand  [ctrl+e] edit", len(tm.filtered), len(tm.all), tcLabel)
 	b.WriteString(styles.StatusText.Render(status))
 	b.WriteByte('\n')
 
@@ -39,8 +39,8 @@ type watchEntry struct {
 // watchModel manages periodic SNMP polling with diff display.
 type watchModel struct {
 	active   bool
-	rootOID  string // OID being polled (dotted string)
-	rootName string // display name of the root node
+	rootOIDs []string // OIDs being polled (dotted strings)
+	rootName string   // display name of the root node
 	node     *mib.Node
 	interval time.Duration
 	pollSeq  uint64 // monotonic counter, incremented on start/stop
@@ -73,25 +73,10 @@ func newWatchModel() watchModel {
 
 // start begins watching the given node's OID.
 func (w *watchModel) start(node *mib.Node, m *mib.Mib) {
-	w.active = true
+	w.reset(node.Name(), []string{node.OID().String()})
 	w.node = node
-	w.rootOID = node.OID().String()
-	w.rootName = node.Name()
-	w.pollSeq++
-	w.pollNum = 0
-	w.polling = false
-	w.prev = nil
-	w.curr = nil
-	w.prevStr = nil
-	w.entries = nil
-	w.hScroll = 0
-	w.lv.SetRows(nil)
 
 	// Detect table mode
-	w.isTable = false
-	w.tbl = nil
-	w.indexCols = 0
-	w.tableColumns = nil
 	if obj := node.Object(); obj != nil {
 		tbl, _ := resolveTable(obj, node.Kind())
 		if tbl != nil {
@@ -109,6 +94,33 @@ func (w *watchModel) start(node *mib.Node, m *mib.Mib) {
 	}
 }
 
+// startInstances begins watching a set of instances, such as one
+// interface's counters, under a display name.
+func (w *watchModel) startInstances(name string, oids []string) {
+	w.reset(name, oids)
+}
+
+// reset clears the previous watch and starts polling oids.
+func (w *watchModel) reset(name string, oids []string) {
+	w.active = true
+	w.node = nil
+	w.rootOIDs = oids
+	w.rootName = name
+	w.pollSeq++
+	w.pollNum = 0
+	w.polling = false
+	w.prev = nil
+	w.curr = nil
+	w.prevStr = nil
+	w.entries = nil
+	w.hScroll = 0
+	w.lv.SetRows(nil)
+	w.isTable = false
+	w.tbl = nil
+	w.indexCols = 0
+	w.tableColumns = nil
+}
+
 // stop ends the watch and invalidates any in-flight polls or ticks.
 func (w *watchModel) stop() {
 	w.active = false
@@ -211,7 +223,7 @@ func (w *watchModel) startPollCmd(sess *snmp.Session) tea.Cmd {
 		return nil
 	}
 	w.polling = true
-	return snmp.WatchPollCmd(sess, w.rootOID, w.pollSeq)
+	return snmp.WatchPollCmd(sess, w.rootOIDs, w.pollSeq)
 }
 
 // scheduleNextTick returns a command to schedule the next poll tick.
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golangsnmp/gomib/mib"
+	"github.com/golangsnmp/mibsh/internal/snmp"
+	"github.com/gosnmp/gosnmp"
+)
+
+// wireDetailed is the number of newest exchanges shown with a full hex
+// dump and TLV breakdown. Older exchanges get one only when something
+// went wrong with them.
+const wireDetailed = 3
+
+// wireReportTitle identifies the packet inspector among reports so it can
+// be refreshed in place.
+const wireReportTitle = "Packet inspector"
+
+// buildWireReport renders the session's recent exchanges, newest first: a
+// summary line per exchange, then the BER of the interesting ones.
+func buildWireReport(sess *snmp.Session, m *mib.Mib) []reportLine {
+	var b reportBuilder
+	exchanges := sess.Wire.Exchanges()
+	b.add(reportPlain, "Agent     %s (v%s)", sess.Target, sess.Version)
+	b.add(reportPlain, "Recorded  %d exchanges, newest first", len(exchanges))
+	if len(exchanges) == 0 {
+		b.blank()
+		b.add(reportMuted, "Nothing sent yet. Run a GET or walk, then press r to refresh.")
+		return b.lines
+	}
+	b.blank()
+	b.add(reportHeading, "%5s  %-28s %-15s %10s %11s  %-18s %8s", "#", "OPERATION", "REQUEST", "ID", "OCTETS", "RESPONSE", "RTT")
+
+	var detailed []snmp.Exchange
+	for i, ex := range exchanges {
+		req, resp := snmp.ParsePacket(ex.Request), snmp.ParsePacket(ex.Response)
+		outcome, kind := wireOutcome(ex, resp)
+		octets := fmt.Sprintf("%d/-", len(ex.Request))
+		rtt := "-"
+		if ex.Response != nil {
+			octets = fmt.Sprintf("%d/%d", len(ex.Request), len(ex.Response))
+			rtt = formatRTT(ex.RTT)
+		}
+		b.add(kind, "%5d  %-28s %-15s %10s %11s  %-18s %8s",
+			ex.Seq, truncate(ex.Op, 28), pduName(req), wireID(req), octets, truncate(outcome, 18), rtt)
+		if i < wireDetailed || kind != reportPlain {
+			detailed = append(detailed, ex)
+		}
+	}
+
+	for _, ex := range detailed {
+		b.blank()
+		writeExchange(&b, ex, m)
+	}
+	return b.lines
+}
+
+// wireOutcome summarizes how an exchange ended and how to style it.
+func wireOutcome(ex snmp.Exchange, resp snmp.PacketInfo) (string, reportLineKind) {
+	switch {
+	case ex.Response == nil:
+		return "no response", reportWarn
+	case resp.Problem != "":
+		return "malformed", reportBad
+	case resp.ErrorStatus != 0 && resp.PDUType == gosnmp.GetResponse:
+		return fmt.Sprintf("%s @%d", resp.ErrorName(), resp.ErrorIndex), reportBad
+	case resp.PDUType == gosnmp.Report:
+		return "report", reportWarn
+	case resp.Encrypted:
+		return "encrypted", reportPlain
+	default:
+		return resp.ErrorName(), reportPlain
+	}
+}
+
+func pduName(p snmp.PacketInfo) string {
+	switch {
+	case p.PDUType != 0:
+		return p.PDUType.String()
+	case p.Encrypted:
+		return "(encrypted)"
+	default:
+		return "?"
+	}
+}
+
+// wireID is the request-id, or the msgID for encrypted SNMPv3 messages.
+func wireID(p snmp.PacketInfo) string {
+	switch {
+	case p.PDUType != 0:
+		return fmt.Sprint(p.RequestID)
+	case p.Version == "3":
+		return fmt.Sprintf("msg %d", p.MsgID)
+	default:
+		return "-"
+	}
+}
+
+func formatRTT(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(100 * time.Microsecond).String()
+}
+
+// writeExchange renders one exchange in full.
+func writeExchange(b *reportBuilder, ex snmp.Exchange, m *mib.Mib) {
+	req := snmp.ParsePacket(ex.Request)
+	b.add(reportHeading, "#%d %s at %s", ex.Seq, ex.Op, ex.Sent.Format("15:04:05.000"))
+	b.add(reportPlain, "  Request   %s", describePacket(req, len(ex.Request)))
+	if ex.Response == nil {
+		b.add(reportWarn, "  Response  none (timed out, or still outstanding)")
+	} else {
+		resp := snmp.ParsePacket(ex.Response)
+		kind := reportPlain
+		if resp.ErrorStatus != 0 || resp.Problem != "" {
+			kind = reportBad
+		}
+		b.add(kind, "  Response  %s, after %s", describePacket(resp, len(ex.Response)), formatRTT(ex.RTT))
+		if resp.ErrorStatus != 0 && resp.PDUType == gosnmp.GetResponse {
+			name := resp.VarBindName(resp.ErrorIndex, m)
+			if name == "" {
+				name = req.VarBindName(resp.ErrorIndex, m)
+			}
+			if name == "" {
+				name = "no such varbind"
+			}
+			b.add(reportBad, "  Offender  error-index %d: %s", resp.ErrorIndex, name)
+		}
+		if resp.Problem != "" {
+			b.add(reportBad, "  Problem   %s", resp.Problem)
+		}
+	}
+
+	writeBER(b, "Request", ex.Request, m)
+	if ex.Response != nil {
+		writeBER(b, "Response", ex.Response, m)
+	}
+}
+
+// describePacket is a one-line account of a message's header.
+func describePacket(p snmp.PacketInfo, size int) string {
+	var parts []string
+	parts = append(parts, pduName(p))
+	if p.Version != "" {
+		parts = append(parts, "v"+p.Version)
+	}
+	if p.Version == "3" {
+		parts = append(parts, fmt.Sprintf("msgID %d", p.MsgID))
+	}
+	if p.PDUType != 0 {
+		parts = append(parts, fmt.Sprintf("request-id %d", p.RequestID))
+		if p.PDUType == gosnmp.GetBulkRequest {
+			parts = append(parts, fmt.Sprintf("non-repeaters %d", p.ErrorStatus),
+				fmt.Sprintf("max-repetitions %d", p.ErrorIndex))
+		} else {
+			parts = append(parts, "error-status "+p.ErrorName(),
+				fmt.Sprintf("error-index %d", p.ErrorIndex))
+		}
+		parts = append(parts, fmt.Sprintf("%d varbinds", len(p.VarBinds)))
+	}
+	parts = append(parts, fmt.Sprintf("%d octets", size))
+	return strings.Join(parts, ", ")
+}
+
+// writeBER adds a hex dump of a message followed by its TLV structure.
+func writeBER(b *reportBuilder, label string, msg []byte, m *mib.Mib) {
+	b.blank()
+	b.add(reportMuted, "  %s BER", label)
+	for off := 0; off < len(msg); off += 16 {
+		row := msg[off:min(off+16, len(msg))]
+		var hexCol, text strings.Builder
+		for i, c := range row {
+			if i == 8 {
+				hexCol.WriteByte(' ')
+			}
+			fmt.Fprintf(&hexCol, "%02x ", c)
+			if c >= 0x20 && c < 0x7f {
+				text.WriteByte(c)
+			} else {
+				text.WriteByte('.')
+			}
+		}
+		b.add(reportPlain, "  %04x  %-49s |%s|", off, hexCol.String(), text.String())
+	}
+	b.add(reportMuted, "  %s TLV", label)
+	writeTLV(b, snmp.DecodeBER(msg), 0, m)
+}
+
+func writeTLV(b *reportBuilder, nodes []snmp.BERNode, depth int, m *mib.Mib) {
+	for _, n := range nodes {
+		indent := strings.Repeat("  ", depth)
+		line := fmt.Sprintf("  %04x  %s%s [%d]", n.Offset, indent, n.TagName(), n.Length)
+		if v := n.Describe(m); v != "" {
+			line += " " + v
+		}
+		kind := reportPlain
+		if n.Problem != "" {
+			line += "  <- " + n.Problem
+			kind = reportBad
+		}
+		b.add(kind, "%s", line)
+		writeTLV(b, n.Children, depth+1, m)
+	}
+}